@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"NotificationManagement/conn"
+	"NotificationManagement/domain"
+	"NotificationManagement/logger"
+	"NotificationManagement/repositories"
+	"NotificationManagement/services"
+	"NotificationManagement/types"
+	"NotificationManagement/utils/importer"
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+var importFlags struct {
+	format       string
+	file         string
+	userID       uint
+	responseType string
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import requests from a HAR file or Postman collection",
+	Long:  `Create a request for every entry of a HAR file or Postman v2.1 collection, owned by the given user`,
+	Run: func(cmd *cobra.Command, args []string) {
+		content, err := os.ReadFile(importFlags.file)
+		if err != nil {
+			logger.Error("Error reading import file", "file", importFlags.file, "error", err)
+			os.Exit(1)
+		}
+
+		app := fx.New(
+			fx.NopLogger,
+			fx.Provide(
+				conn.NewDB,
+				conn.Redis,
+
+				repositories.NewCurlRequestRepository,
+				repositories.NewAdditionalFieldsRepository,
+				repositories.NewResponseSnapshotRepository,
+				repositories.NewSecretRepository,
+				repositories.NewHostRuleRepository,
+				repositories.NewUserRepository,
+				repositories.NewTokenCache,
+				repositories.NewHostLimiter,
+
+				services.NewCurlService,
+				services.NewSecretService,
+				services.NewHostRuleService,
+			),
+			fx.Invoke(func(curlService domain.CurlService) error {
+				result, err := curlService.ImportRequests(context.Background(), importFlags.userID, importFlags.format, &types.CurlImportRequest{
+					ResponseType: importFlags.responseType,
+					Content:      content,
+				})
+				if err != nil {
+					return err
+				}
+				for _, created := range result.Created {
+					logger.Info("Imported request", "id", created.ID, "method", created.Method, "url", created.URL)
+				}
+				for _, skipped := range result.Skipped {
+					logger.Warn("Skipped request", "name", skipped.Name, "reason", skipped.Reason)
+				}
+				logger.Info("Import finished", "created", len(result.Created), "skipped", len(result.Skipped))
+				return nil
+			}),
+		)
+		if err := app.Err(); err != nil {
+			logger.Error("Import failed", "error", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importFlags.format, "format", importer.FormatHAR, "input format: har or postman")
+	importCmd.Flags().StringVarP(&importFlags.file, "file", "f", "", "path of the HAR file or Postman collection")
+	importCmd.Flags().UintVar(&importFlags.userID, "user-id", 0, "id of the user that owns the imported requests")
+	importCmd.Flags().StringVar(&importFlags.responseType, "response-type", "", "response type of every imported request (guessed when empty)")
+	_ = importCmd.MarkFlagRequired("file")
+	_ = importCmd.MarkFlagRequired("user-id")
+	RootCmd.AddCommand(importCmd)
+}
@@ -27,15 +27,27 @@ var workerCmd = &cobra.Command{
 				conn.NewDB,
 				conn.NewAsynq,
 				conn.NewAsynqInspector,
+				conn.Redis,
 
 				repositories.NewReminderRepository,
+				repositories.NewReminderRunRepository,
 				repositories.NewAIModelRepository,
 				repositories.NewTelegramRepository,
 				repositories.NewUserRepository,
 				repositories.NewCurlRequestRepository,
 				repositories.NewGeminiRepository,
 				repositories.NewDeepseekModelRepository,
+				repositories.NewOpenAIModelRepository,
+				repositories.NewLocalModelRepository,
+				repositories.NewAnthropicModelRepository,
 				repositories.NewAdditionalFieldsRepository,
+				repositories.NewResponseSnapshotRepository,
+				repositories.NewFeedEntryRepository,
+				repositories.NewSecretRepository,
+				repositories.NewHostRuleRepository,
+				repositories.NewPromptTemplateRepository,
+				repositories.NewTokenCache,
+				repositories.NewHostLimiter,
 
 				services.NewReminderService,
 				services.NewAsynqService,
@@ -43,8 +55,14 @@ var workerCmd = &cobra.Command{
 				services.NewTelegramAPI,
 				services.NewGeminiService,
 				services.NewDeepseekModelService,
+				services.NewOpenAIService,
+				services.NewLocalService,
+				services.NewAnthropicService,
 				services.NewAIDispatcher,
 				services.NewCurlService,
+				services.NewSecretService,
+				services.NewHostRuleService,
+				services.NewPromptTemplateService,
 				services.NewAIModelService,
 
 				worker.NewReminderTaskHandler,
@@ -19,11 +19,13 @@ type Config struct {
 	Logger      LoggerConfig      `mapstructure:"logger" tag:"obj"`
 	Keycloak    KeycloakConfig    `mapstructure:"keycloak" tag:"obj"`
 	Telegram    TelegramConfig    `mapstructure:"telegram" tag:"obj"`
+	Fetch       FetchConfig       `mapstructure:"fetch" tag:"obj"`
 	Development DevelopmentConfig `mapstructure:"development" tag:"obj"`
 }
 type DevelopmentConfig struct {
-	GeminiKey string `mapstructure:"geminikey"`
-	OpenAIKey string `mapstructure:"openaikey"`
+	GeminiKey    string `mapstructure:"geminikey"`
+	OpenAIKey    string `mapstructure:"openaikey"`
+	AnthropicKey string `mapstructure:"anthropickey"`
 }
 type AppConfig struct {
 	Name       string `mapstructure:"name"`
@@ -84,6 +86,28 @@ type TelegramConfig struct {
 	Enabled *bool  `mapstructure:"enabled"`
 }
 
+// FetchConfig holds the defaults applied when a CurlRequest doesn't set its own limits.
+type FetchConfig struct {
+	ConnectTimeout  *int   `mapstructure:"connectTimeout"` // in seconds
+	Timeout         *int   `mapstructure:"timeout"`        // in seconds
+	MaxRetries      *int   `mapstructure:"maxRetries"`
+	RetryBackoff    *int   `mapstructure:"retryBackoff"`    // in milliseconds
+	MaxResponseSize *int   `mapstructure:"maxResponseSize"` // in bytes
+	AllowedStatuses string `mapstructure:"allowedStatuses"`
+	SnapshotLimit   *int   `mapstructure:"snapshotLimit"`
+	ContentBudget   *int   `mapstructure:"contentBudget"` // in bytes of text sent to the AI
+	MaxPages        *int   `mapstructure:"maxPages"`      // pages followed for paginated sources
+	// AllowPrivateNetworks lets sources reach loopback, private and link-local addresses.
+	AllowPrivateNetworks *bool `mapstructure:"allowPrivateNetworks"`
+	// HostRateLimit is the number of requests per minute all workers together
+	// send to a single host, with bursts of up to HostBurst. 0 disables it.
+	HostRateLimit *int   `mapstructure:"hostRateLimit"`
+	HostBurst     *int   `mapstructure:"hostBurst"`
+	UserAgent     string `mapstructure:"userAgent"` // used when a source doesn't set its own
+	// RespectRobotsTxt skips sources whose robots.txt disallows our User-Agent.
+	RespectRobotsTxt *bool `mapstructure:"respectRobotsTxt"`
+}
+
 type AWSConfig struct {
 	Region          string        `mapstructure:"region"`
 	AccessKeyID     string        `mapstructure:"accessKeyID"`
@@ -233,6 +257,22 @@ func loadDefaults() *Config {
 			Token:   "",
 			Enabled: &FalsePointer,
 		},
+		Fetch: FetchConfig{
+			ConnectTimeout:       helper.ToInt("10"),
+			Timeout:              helper.ToInt("30"),
+			MaxRetries:           helper.ToInt("2"),
+			RetryBackoff:         helper.ToInt("500"),
+			MaxResponseSize:      helper.ToInt("5242880"),
+			AllowedStatuses:      "200-299",
+			SnapshotLimit:        helper.ToInt("5"),
+			ContentBudget:        helper.ToInt("100000"),
+			MaxPages:             helper.ToInt("10"),
+			AllowPrivateNetworks: &FalsePointer,
+			HostRateLimit:        helper.ToInt("60"),
+			HostBurst:            helper.ToInt("5"),
+			UserAgent:            "NotificationManagement/1.0",
+			RespectRobotsTxt:     &FalsePointer,
+		},
 		Development: DevelopmentConfig{
 			GeminiKey: "",
 		},
@@ -338,9 +378,26 @@ func loadFromEnv() *Config {
 			Token:   os.Getenv(EnvTelegramToken),
 			Enabled: helper.ToBool(os.Getenv(EnvTelegramEnabled)),
 		},
+		Fetch: FetchConfig{
+			ConnectTimeout:       helper.ToInt(os.Getenv(EnvFetchConnectTimeout)),
+			Timeout:              helper.ToInt(os.Getenv(EnvFetchTimeout)),
+			MaxRetries:           helper.ToInt(os.Getenv(EnvFetchMaxRetries)),
+			RetryBackoff:         helper.ToInt(os.Getenv(EnvFetchRetryBackoff)),
+			MaxResponseSize:      helper.ToInt(os.Getenv(EnvFetchMaxResponseSize)),
+			AllowedStatuses:      os.Getenv(EnvFetchAllowedStatuses),
+			SnapshotLimit:        helper.ToInt(os.Getenv(EnvFetchSnapshotLimit)),
+			ContentBudget:        helper.ToInt(os.Getenv(EnvFetchContentBudget)),
+			MaxPages:             helper.ToInt(os.Getenv(EnvFetchMaxPages)),
+			AllowPrivateNetworks: helper.ToBool(os.Getenv(EnvFetchAllowPrivateNetworks)),
+			HostRateLimit:        helper.ToInt(os.Getenv(EnvFetchHostRateLimit)),
+			HostBurst:            helper.ToInt(os.Getenv(EnvFetchHostBurst)),
+			UserAgent:            os.Getenv(EnvFetchUserAgent),
+			RespectRobotsTxt:     helper.ToBool(os.Getenv(EnvFetchRespectRobotsTxt)),
+		},
 		Development: DevelopmentConfig{
-			GeminiKey: os.Getenv(EnvGeminiKey),
-			OpenAIKey: os.Getenv(EnvOpenaiKey),
+			GeminiKey:    os.Getenv(EnvGeminiKey),
+			OpenAIKey:    os.Getenv(EnvOpenaiKey),
+			AnthropicKey: os.Getenv(EnvAnthropicKey),
 		},
 	}
 	setViperFields(c, "")
@@ -397,6 +454,10 @@ func Telegram() TelegramConfig {
 	return appConfig.Telegram
 }
 
+func Fetch() FetchConfig {
+	return appConfig.Fetch
+}
+
 func GetDSN() string {
 	db := Database()
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
@@ -15,8 +15,9 @@ const (
 	EnvAppEnv     = "APP_ENV"
 	EnvAppDomain  = "APP_DOMAIN"
 
-	EnvGeminiKey = "GEMINI_KEY"
-	EnvOpenaiKey = "OPENAI_KEY"
+	EnvGeminiKey    = "GEMINI_KEY"
+	EnvOpenaiKey    = "OPENAI_KEY"
+	EnvAnthropicKey = "ANTHROPIC_KEY"
 
 	EnvDBHost     = "DB_HOST"
 	EnvDBPort     = "DB_PORT"
@@ -50,5 +51,20 @@ const (
 	EnvTelegramToken   = "TELEGRAM_TOKEN"
 	EnvTelegramEnabled = "TELEGRAM_ENABLED"
 
+	EnvFetchConnectTimeout       = "FETCH_CONNECT_TIMEOUT"
+	EnvFetchTimeout              = "FETCH_TIMEOUT"
+	EnvFetchMaxRetries           = "FETCH_MAX_RETRIES"
+	EnvFetchRetryBackoff         = "FETCH_RETRY_BACKOFF"
+	EnvFetchMaxResponseSize      = "FETCH_MAX_RESPONSE_SIZE"
+	EnvFetchAllowedStatuses      = "FETCH_ALLOWED_STATUSES"
+	EnvFetchSnapshotLimit        = "FETCH_SNAPSHOT_LIMIT"
+	EnvFetchContentBudget        = "FETCH_CONTENT_BUDGET"
+	EnvFetchAllowPrivateNetworks = "FETCH_ALLOW_PRIVATE_NETWORKS"
+	EnvFetchMaxPages             = "FETCH_MAX_PAGES"
+	EnvFetchHostRateLimit        = "FETCH_HOST_RATE_LIMIT"
+	EnvFetchHostBurst            = "FETCH_HOST_BURST"
+	EnvFetchUserAgent            = "FETCH_USER_AGENT"
+	EnvFetchRespectRobotsTxt     = "FETCH_RESPECT_ROBOTS_TXT"
+
 	EnvAPIKeyEncryptionSecret = "API_KEY_ENCRYPTION_SECRET"
 )
@@ -8,9 +8,15 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	logger2 "gorm.io/gorm/logger"
+	"regexp"
+	"strings"
 	"time"
 )
 
+const aiModelTypeCheck = "chk_ai_models_type"
+
+var quotedValue = regexp.MustCompile(`'[^']*'`)
+
 func NewDB() *gorm.DB {
 	gormLogger := NewGormZapLogger(
 		logger2.Info,
@@ -30,8 +36,17 @@ func NewDB() *gorm.DB {
 		&models.RequestAIModel{},
 		&models.DeepseekModel{},
 		&models.AdditionalFields{},
+		&models.RequestStep{},
 		&models.User{},
 		&models.Telegram{},
+		&models.Secret{},
+		&models.HostRule{},
+		&models.ReminderRun{},
+		&models.ReminderVerdict{},
+		&models.ResponseSnapshot{},
+		&models.FeedEntry{},
+		&models.PromptTemplate{},
+		&models.PromptTemplateVersion{},
 	); err != nil {
 		logger.Fatal("Failed to auto-migrate database schema", "error", err)
 		panic(err.Error())
@@ -42,6 +57,50 @@ func NewDB() *gorm.DB {
 		logger.Fatal("Failed to auto-migrate database schema", "error", err)
 		panic(err.Error())
 	}
+	if err := refreshAIModelTypeCheck(dB); err != nil {
+		logger.Fatal("Failed to migrate the AI model type check", "error", err)
+		panic(err.Error())
+	}
 	log.Info("Database connection successful...")
 	return dB
 }
+
+// refreshAIModelTypeCheck recreates the ai_models type check when it is
+// missing a type listed on the model. AutoMigrate never changes a check
+// constraint that already exists, so new AI model types need this.
+func refreshAIModelTypeCheck(db *gorm.DB) error {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&models.AnthropicModel{}); err != nil {
+		return err
+	}
+	check, ok := stmt.Schema.ParseCheckConstraints()[aiModelTypeCheck]
+	if !ok {
+		return nil
+	}
+
+	var definition string
+	err := db.Raw("SELECT pg_get_constraintdef(oid) FROM pg_constraint WHERE conrelid = ?::regclass AND conname = ?", stmt.Table, aiModelTypeCheck).
+		Scan(&definition).Error
+	if err != nil {
+		return err
+	}
+	current := true
+	for _, value := range quotedValue.FindAllString(check.Constraint, -1) {
+		if !strings.Contains(definition, value) {
+			current = false
+			break
+		}
+	}
+	if definition != "" && current {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if definition != "" {
+			if err := tx.Migrator().DropConstraint(&models.AnthropicModel{}, aiModelTypeCheck); err != nil {
+				return err
+			}
+		}
+		return tx.Migrator().CreateConstraint(&models.AnthropicModel{}, aiModelTypeCheck)
+	})
+}
@@ -10,14 +10,16 @@ import (
 )
 
 type CurlControllerImpl struct {
-	CurlService domain.CurlService
-	UserService domain.UserService
+	CurlService    domain.CurlService
+	UserService    domain.UserService
+	PromptTemplate domain.PromptTemplateService
 }
 
-func NewCurlController(curlService domain.CurlService, userService domain.UserService) domain.CurlController {
+func NewCurlController(curlService domain.CurlService, userService domain.UserService, promptTemplateService domain.PromptTemplateService) domain.CurlController {
 	return &CurlControllerImpl{
-		CurlService: curlService,
-		UserService: userService,
+		CurlService:    curlService,
+		UserService:    userService,
+		PromptTemplate: promptTemplateService,
 	}
 }
 
@@ -33,14 +35,16 @@ func (cc *CurlControllerImpl) CurlHandler(c echo.Context) error {
 	for i, _ := range *model.AdditionalFields {
 		(*model.AdditionalFields)[i].ID = 0
 	}
-	if model.UserID == 0 {
-		model.UserID = helper.GetUserId(c)
+	model.UserID = helper.GetUserId(c)
+	ctx := c.Request().Context()
+	if err := cc.PromptTemplate.CheckTemplateRef(ctx, model.UserID, model); err != nil {
+		return err
 	}
-	err = cc.CurlService.CreateModel(c.Request().Context(), model)
+	err = cc.CurlService.CreateModel(ctx, model)
 	if err != nil {
 		return err
 	}
-	resp, err := cc.CurlService.ProcessCurlRequest(nil, model)
+	resp, err := cc.CurlService.ProcessCurlRequest(ctx, model)
 	if err != nil {
 		return err
 	}
@@ -77,11 +81,16 @@ func (cc *CurlControllerImpl) UpdateCurlRequest(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	if model.UserID == 0 {
-		model.UserID = helper.GetUserId(c)
+	model.UserID = helper.GetUserId(c)
+	ctx := c.Request().Context()
+	if _, err := cc.CurlService.GetUserRequest(ctx, model.UserID, id); err != nil {
+		return err
+	}
+	if err := cc.PromptTemplate.CheckTemplateRef(ctx, model.UserID, model); err != nil {
+		return err
 	}
 
-	model, err = cc.CurlService.UpdateModel(c.Request().Context(), id, model)
+	model, err = cc.CurlService.UpdateModel(ctx, id, model)
 	if err != nil {
 		return err
 	}
@@ -102,3 +111,82 @@ func (cc *CurlControllerImpl) DeleteCurlRequest(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "CurlRequest deleted successfully"})
 }
+
+func (cc *CurlControllerImpl) PreviewExtraction(c echo.Context) error {
+	id, err := helper.ParseIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req types.ExtractPreviewRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	curlRequest, err := cc.CurlService.GetUserRequest(ctx, helper.GetUserId(c), id)
+	if err != nil {
+		return err
+	}
+
+	resp, err := cc.CurlService.PreviewExtraction(ctx, curlRequest, req.Extractor)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// TestCurlRequest runs a request definition once and reports what it would
+// produce, without saving it.
+func (cc *CurlControllerImpl) TestCurlRequest(c echo.Context) error {
+	var req types.CurlRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+	model, err := req.ToModel()
+	if err != nil {
+		return err
+	}
+	model.UserID = helper.GetUserId(c)
+	ctx := c.Request().Context()
+	if err := cc.PromptTemplate.CheckTemplateRef(ctx, model.UserID, model); err != nil {
+		return err
+	}
+
+	resp, err := cc.CurlService.TestRequest(ctx, model)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ImportCurlRequests creates requests from a HAR file or Postman collection;
+// the format is taken from the path.
+func (cc *CurlControllerImpl) ImportCurlRequests(c echo.Context) error {
+	var req types.CurlImportRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+	resp, err := cc.CurlService.ImportRequests(c.Request().Context(), helper.GetUserId(c), c.Param("format"), &req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (cc *CurlControllerImpl) ExportCurlRequest(c echo.Context) error {
+	id, err := helper.ParseIDFromContext(c)
+	if err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	curlRequest, err := cc.CurlService.GetUserRequest(ctx, helper.GetUserId(c), id)
+	if err != nil {
+		return err
+	}
+
+	resp, err := cc.CurlService.ExportCurl(ctx, curlRequest)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
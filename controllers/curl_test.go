@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"NotificationManagement/domain"
+	"NotificationManagement/middleware"
+	"NotificationManagement/models"
+	"NotificationManagement/services"
+	"NotificationManagement/utils/errutil"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+type stubCurlRepo struct {
+	domain.CurlRequestRepository
+	requests map[uint]*models.CurlRequest
+}
+
+func (r *stubCurlRepo) GetByID(ctx context.Context, id uint, preloads *[]string) (*models.CurlRequest, error) {
+	req, ok := r.requests[id]
+	if !ok {
+		return nil, errutil.NewAppError(errutil.ErrRecordNotFound, errors.New("not found"))
+	}
+	return req, nil
+}
+
+func exportContext(userID uint, id string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	return &middleware.CustomContext{Context: c, UserID: userID}, rec
+}
+
+func TestExportCurlRequestRejectsOtherUsers(t *testing.T) {
+	repo := &stubCurlRepo{requests: map[uint]*models.CurlRequest{
+		1: {UserID: 7, URL: "https://example.com/feed", Method: http.MethodGet},
+	}}
+	cc := NewCurlController(services.NewCurlService(repo, nil, nil, nil, nil, nil, nil), nil, nil)
+
+	c, rec := exportContext(7, "1")
+	if err := cc.ExportCurlRequest(c); err != nil {
+		t.Fatalf("owner export failed: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("owner export status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	c, rec = exportContext(8, "1")
+	err := cc.ExportCurlRequest(c)
+	var appErr *errutil.AppError
+	if !errors.As(err, &appErr) || appErr.Code != errutil.ErrRecordNotFound {
+		t.Fatalf("non-owner export error = %v, want %s", err, errutil.ErrRecordNotFound.Code)
+	}
+	if rec.Body.Len() != 0 {
+		t.Fatalf("non-owner export wrote a response: %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"NotificationManagement/controllers/helper"
+	"NotificationManagement/domain"
+	"NotificationManagement/types"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type HostRuleControllerImpl struct {
+	Service domain.HostRuleService
+}
+
+func NewHostRuleController(service domain.HostRuleService) domain.HostRuleController {
+	return &HostRuleControllerImpl{Service: service}
+}
+
+func (hc *HostRuleControllerImpl) CreateHostRule(c echo.Context) error {
+	var req types.HostRuleRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	rule, err := req.ToModel()
+	if err != nil {
+		return err
+	}
+	err = hc.Service.CreateModel(c.Request().Context(), rule)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, types.FromHostRuleModel(rule))
+}
+
+func (hc *HostRuleControllerImpl) GetHostRuleByID(c echo.Context) error {
+	id, err := helper.ParseIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	rule, err := hc.Service.GetModelById(c.Request().Context(), id, nil)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, types.FromHostRuleModel(rule))
+}
+
+func (hc *HostRuleControllerImpl) GetAllHostRules(c echo.Context) error {
+	limit, offset := helper.ParseLimitAndOffset(c)
+
+	rules, err := hc.Service.GetAllModels(c.Request().Context(), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]*types.HostRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		responses = append(responses, types.FromHostRuleModel(&rule))
+	}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (hc *HostRuleControllerImpl) UpdateHostRule(c echo.Context) error {
+	id, err := helper.ParseIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req types.HostRuleRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	rule, err := req.ToModel()
+	if err != nil {
+		return err
+	}
+	rule, err = hc.Service.UpdateModel(c.Request().Context(), id, rule)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, types.FromHostRuleModel(rule))
+}
+
+func (hc *HostRuleControllerImpl) DeleteHostRule(c echo.Context) error {
+	id, err := helper.ParseIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	err = hc.Service.DeleteModel(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, map[string]string{"message": "Host rule deleted successfully"})
+}
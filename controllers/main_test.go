@@ -0,0 +1,17 @@
+package controllers
+
+import (
+	"NotificationManagement/config"
+	"NotificationManagement/logger"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	os.Setenv(config.EnvAWSConfigServiceEnabled, "false")
+	os.Setenv(config.EnvLogFilePath, filepath.Join(os.TempDir(), "notification-management-test", "app.log"))
+	config.LoadConfig()
+	logger.Init()
+	os.Exit(m.Run())
+}
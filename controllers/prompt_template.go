@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"NotificationManagement/controllers/helper"
+	"NotificationManagement/domain"
+	"NotificationManagement/types"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PromptTemplateControllerImpl struct {
+	PromptTemplateService domain.PromptTemplateService
+}
+
+func NewPromptTemplateController(service domain.PromptTemplateService) domain.PromptTemplateController {
+	return &PromptTemplateControllerImpl{PromptTemplateService: service}
+}
+
+func (pc *PromptTemplateControllerImpl) CreatePromptTemplate(c echo.Context) error {
+	var req types.PromptTemplateRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+	template, version, err := req.ToModel(helper.GetUserId(c))
+	if err != nil {
+		return err
+	}
+	err = pc.PromptTemplateService.CreateTemplate(c.Request().Context(), template, version)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, types.FromPromptTemplateModel(template))
+}
+
+func (pc *PromptTemplateControllerImpl) GetPromptTemplateByID(c echo.Context) error {
+	id, err := helper.ParseIDFromContext(c)
+	if err != nil {
+		return err
+	}
+	template, err := pc.PromptTemplateService.GetUserTemplate(c.Request().Context(), helper.GetUserId(c), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, types.FromPromptTemplateModel(template))
+}
+
+func (pc *PromptTemplateControllerImpl) GetAllPromptTemplates(c echo.Context) error {
+	limit, offset := helper.ParseLimitAndOffset(c)
+
+	templates, err := pc.PromptTemplateService.GetUserTemplates(c.Request().Context(), helper.GetUserId(c), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]*types.PromptTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		responses = append(responses, types.FromPromptTemplateModel(&template))
+	}
+	return c.JSON(http.StatusOK, responses)
+}
+
+// UpdatePromptTemplate adds a new version; earlier versions stay available.
+func (pc *PromptTemplateControllerImpl) UpdatePromptTemplate(c echo.Context) error {
+	id, err := helper.ParseIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req types.PromptTemplateRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+	userID := helper.GetUserId(c)
+	template, version, err := req.ToModel(userID)
+	if err != nil {
+		return err
+	}
+	template, err = pc.PromptTemplateService.UpdateTemplate(c.Request().Context(), userID, id, template.Name, version)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, types.FromPromptTemplateModel(template))
+}
+
+func (pc *PromptTemplateControllerImpl) DeletePromptTemplate(c echo.Context) error {
+	id, err := helper.ParseIDFromContext(c)
+	if err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	if _, err := pc.PromptTemplateService.GetUserTemplate(ctx, helper.GetUserId(c), id); err != nil {
+		return err
+	}
+
+	err = pc.PromptTemplateService.DeleteModel(ctx, id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, map[string]string{"message": "Prompt template deleted successfully"})
+}
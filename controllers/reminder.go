@@ -29,6 +29,9 @@ func (rc *ReminderControllerImpl) CreateReminder(c echo.Context) error {
 		return err
 	}
 	ctx := c.Request().Context()
+	if err := rc.reminderService.CheckRule(ctx, reminder); err != nil {
+		return err
+	}
 	err = rc.reminderService.CreateModel(ctx, reminder)
 	if err != nil {
 		return err
@@ -93,7 +96,11 @@ func (rc *ReminderControllerImpl) UpdateReminder(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	reminder, err = rc.reminderService.UpdateModel(c.Request().Context(), id, reminder)
+	ctx := c.Request().Context()
+	if err := rc.reminderService.CheckRule(ctx, reminder); err != nil {
+		return err
+	}
+	reminder, err = rc.reminderService.UpdateModel(ctx, id, reminder)
 	if err != nil {
 		return err
 	}
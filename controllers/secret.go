@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"NotificationManagement/controllers/helper"
+	"NotificationManagement/domain"
+	"NotificationManagement/types"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SecretControllerImpl struct {
+	SecretService domain.SecretService
+}
+
+func NewSecretController(service domain.SecretService) domain.SecretController {
+	return &SecretControllerImpl{SecretService: service}
+}
+
+func (sc *SecretControllerImpl) CreateSecret(c echo.Context) error {
+	var req types.SecretRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+	secret, err := req.ToModel(helper.GetUserId(c))
+	if err != nil {
+		return err
+	}
+	err = sc.SecretService.CreateModel(c.Request().Context(), secret)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, types.FromSecretModel(secret))
+}
+
+func (sc *SecretControllerImpl) GetAllSecrets(c echo.Context) error {
+	limit, offset := helper.ParseLimitAndOffset(c)
+
+	secrets, err := sc.SecretService.GetUserSecrets(c.Request().Context(), helper.GetUserId(c), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]*types.SecretResponse, 0, len(secrets))
+	for _, secret := range secrets {
+		responses = append(responses, types.FromSecretModel(&secret))
+	}
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (sc *SecretControllerImpl) UpdateSecret(c echo.Context) error {
+	id, err := helper.ParseIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req types.SecretRequest
+	if err := helper.BindAndValidate(c, &req); err != nil {
+		return err
+	}
+	userID := helper.GetUserId(c)
+	secret, err := req.ToModel(userID)
+	if err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	if _, err := sc.SecretService.GetUserSecret(ctx, userID, id); err != nil {
+		return err
+	}
+	secret, err = sc.SecretService.UpdateModel(ctx, id, secret)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, types.FromSecretModel(secret))
+}
+
+func (sc *SecretControllerImpl) DeleteSecret(c echo.Context) error {
+	id, err := helper.ParseIDFromContext(c)
+	if err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	if _, err := sc.SecretService.GetUserSecret(ctx, helper.GetUserId(c), id); err != nil {
+		return err
+	}
+
+	err = sc.SecretService.DeleteModel(ctx, id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, map[string]string{"message": "Secret deleted successfully"})
+}
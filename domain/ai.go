@@ -24,6 +24,7 @@ type AIModelRepository interface {
 
 type AiDispatcher interface {
 	RequestProcessor(c context.Context, m *models.AIModel, requestId uint) (map[string]interface{}, error)
+	RequestChain(c context.Context, chain []models.RequestAIModel, requestId uint) (map[string]interface{}, *models.RequestAIModel, error)
 	ProcessCreateModel(ctx context.Context, model models.AIModelInterface) error
 	ProcessModelById(ctx context.Context, id uint) (any, error)
 	ProcessAllAIModels(ctx context.Context) []any
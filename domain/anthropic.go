@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"NotificationManagement/models"
+)
+
+type AnthropicService interface {
+	AIService[models.AnthropicModel]
+}
+
+type AnthropicModelRepository interface {
+	Repository[models.AnthropicModel, uint]
+}
@@ -4,24 +4,65 @@ import (
 	"NotificationManagement/models"
 	"NotificationManagement/types"
 	"context"
+	"time"
+
 	"github.com/labstack/echo/v4"
 )
 
 type CurlService interface {
 	CommonService[models.CurlRequest]
+	GetUserRequest(c context.Context, userID uint, id uint) (*models.CurlRequest, error)
 	ProcessCurlRequest(c context.Context, req *models.CurlRequest) (*types.CurlResponse, error)
+	CheckForChanges(c context.Context, req *models.CurlRequest, state types.FetchState) (*types.SourceChange, error)
+	WithPrefetched(c context.Context, requestId uint, resp *types.CurlResponse) context.Context
+	PreviewExtraction(c context.Context, req *models.CurlRequest, extractor string) (*types.CurlResponse, error)
+	TestRequest(c context.Context, req *models.CurlRequest) (*types.CurlTestResponse, error)
+	ImportRequests(c context.Context, userID uint, format string, req *types.CurlImportRequest) (*types.CurlImportResponse, error)
+	ExportCurl(c context.Context, req *models.CurlRequest) (*types.CurlExportResponse, error)
 }
 
 type CurlRequestRepository interface {
 	Repository[models.CurlRequest, uint]
+	UpdateRefreshToken(ctx context.Context, id uint, refreshToken string) error
+}
+
+// TokenCache keeps the access tokens fetched for sources until shortly before they expire.
+type TokenCache interface {
+	Get(ctx context.Context, key string) (string, bool, error)
+	Set(ctx context.Context, key, token string, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+// HostLimiter spaces out requests to the same host across all workers.
+type HostLimiter interface {
+	// Reserve takes the next free slot for a request to host and returns how
+	// long to wait before sending it.
+	Reserve(ctx context.Context, host string, perMinute, burst int) (time.Duration, error)
 }
+
 type AdditionalFieldsRepository interface {
 	Repository[models.AdditionalFields, uint]
 }
 
+type ResponseSnapshotRepository interface {
+	Repository[models.ResponseSnapshot, uint]
+	GetLatest(ctx context.Context, requestID uint, limit int) ([]models.ResponseSnapshot, error)
+	Prune(ctx context.Context, requestID uint, keep int) error
+}
+
+type FeedEntryRepository interface {
+	Repository[models.FeedEntry, uint]
+	GetSeenGUIDs(ctx context.Context, reminderID uint, guids []string) ([]string, error)
+	ReplaceSeen(ctx context.Context, reminderID uint, added []string, current []string) error
+}
+
 type CurlController interface {
 	CurlHandler(c echo.Context) error
 	GetCurlRequestByID(c echo.Context) error
 	UpdateCurlRequest(c echo.Context) error
 	DeleteCurlRequest(c echo.Context) error
+	PreviewExtraction(c echo.Context) error
+	TestCurlRequest(c echo.Context) error
+	ImportCurlRequests(c echo.Context) error
+	ExportCurlRequest(c echo.Context) error
 }
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"NotificationManagement/models"
+	"NotificationManagement/utils/netguard"
+	"context"
+
+	"github.com/labstack/echo/v4"
+)
+
+type HostRuleService interface {
+	CommonService[models.HostRule]
+	PolicyFor(ctx context.Context, userID uint) (*netguard.Policy, error)
+}
+
+type HostRuleRepository interface {
+	Repository[models.HostRule, uint]
+	FindByRoles(ctx context.Context, roles []string) ([]models.HostRule, error)
+}
+
+type HostRuleController interface {
+	CreateHostRule(c echo.Context) error
+	GetHostRuleByID(c echo.Context) error
+	GetAllHostRules(c echo.Context) error
+	UpdateHostRule(c echo.Context) error
+	DeleteHostRule(c echo.Context) error
+}
@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"NotificationManagement/models"
+)
+
+type LocalService interface {
+	AIService[models.LocalModel]
+}
+
+type LocalModelRepository interface {
+	Repository[models.LocalModel, uint]
+}
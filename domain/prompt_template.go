@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"NotificationManagement/models"
+	"NotificationManagement/types"
+	"context"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PromptTemplateService interface {
+	CommonService[models.PromptTemplate]
+	GetUserTemplate(ctx context.Context, userID uint, id uint) (*models.PromptTemplate, error)
+	GetUserTemplates(ctx context.Context, userID uint, limit, offset int) ([]models.PromptTemplate, error)
+	CreateTemplate(ctx context.Context, template *models.PromptTemplate, version *models.PromptTemplateVersion) error
+	UpdateTemplate(ctx context.Context, userID uint, id uint, name string, version *models.PromptTemplateVersion) (*models.PromptTemplate, error)
+	// CheckTemplateRef verifies that the template and version a source refers
+	// to exist and belong to userID.
+	CheckTemplateRef(ctx context.Context, userID uint, req *models.CurlRequest) error
+	// BuildPrompt turns a fetched source into the prompt for the AI, rendering
+	// the source's template or, without one, using its body as the question.
+	BuildPrompt(ctx context.Context, req *models.CurlRequest, resp *types.CurlResponse) (*types.Prompt, error)
+}
+
+type PromptTemplateRepository interface {
+	Repository[models.PromptTemplate, uint]
+	GetAllByUser(ctx context.Context, userID uint, limit, offset int) ([]models.PromptTemplate, error)
+	GetVersion(ctx context.Context, templateID, version uint) (*models.PromptTemplateVersion, error)
+	AddVersion(ctx context.Context, templateID uint, name string, version *models.PromptTemplateVersion) error
+}
+
+type PromptTemplateController interface {
+	CreatePromptTemplate(c echo.Context) error
+	GetPromptTemplateByID(c echo.Context) error
+	GetAllPromptTemplates(c echo.Context) error
+	UpdatePromptTemplate(c echo.Context) error
+	DeletePromptTemplate(c echo.Context) error
+}
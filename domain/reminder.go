@@ -10,10 +10,16 @@ import (
 type ReminderService interface {
 	CommonService[models.Reminder]
 	ProcessAndSendReminders(ctx context.Context, reminderId uint) error
+	CheckRule(ctx context.Context, reminder *models.Reminder) error
 }
 
 type ReminderRepository interface {
 	Repository[models.Reminder, uint]
+	UpdateFetchState(ctx context.Context, id uint, contentHash, etag, lastModified string) error
+}
+
+type ReminderRunRepository interface {
+	Repository[models.ReminderRun, uint]
 }
 
 type ReminderController interface {
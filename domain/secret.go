@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"NotificationManagement/models"
+	"context"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SecretService interface {
+	CommonService[models.Secret]
+	GetUserSecret(ctx context.Context, userID uint, id uint) (*models.Secret, error)
+	GetUserSecrets(ctx context.Context, userID uint, limit, offset int) ([]models.Secret, error)
+	Expander(ctx context.Context, userID uint, vars map[string]string) func(string) (string, error)
+}
+
+type SecretRepository interface {
+	Repository[models.Secret, uint]
+	FindByUserAndName(ctx context.Context, userID uint, name string) (*models.Secret, error)
+	GetAllByUser(ctx context.Context, userID uint, limit, offset int) ([]models.Secret, error)
+}
+
+type SecretController interface {
+	CreateSecret(c echo.Context) error
+	GetAllSecrets(c echo.Context) error
+	UpdateSecret(c echo.Context) error
+	DeleteSecret(c echo.Context) error
+}
@@ -12,7 +12,7 @@ type AIModelInterface interface {
 
 type AIModel struct {
 	gorm.Model
-	Type    string  `gorm:"size:10;check:type IN ('local','openai','gemini','deepseek')"`
+	Type    string  `gorm:"size:10;check:type IN ('local','openai','gemini','deepseek','anthropic')"`
 	BaseURL *string `gorm:"size:500" json:"base_url,omitempty"`
 }
 
@@ -30,6 +30,22 @@ type DeepseekModel struct {
 	Size      int64  `json:"size"`
 }
 
+// LocalModel is served by any OpenAI-compatible endpoint at BaseURL, such as
+// llama.cpp, vLLM, LM Studio or LocalAI. The API key is optional.
+type LocalModel struct {
+	AIModel   `mapper:"inherit"`
+	Name      string          `gorm:"size:255;not null" json:"name"`
+	ModelName string          `gorm:"size:255;not null;check:model_name <> '';index:idx_ai_model_model_url,unique" json:"model"`
+	APISecret EncryptedString `gorm:"size:500" json:"-"`
+}
+
+type AnthropicModel struct {
+	AIModel   `mapper:"inherit"`
+	Name      string          `gorm:"size:255;not null" json:"name"`
+	ModelName string          `gorm:"size:255;not null;check:model_name <> '';index:idx_ai_model_model_secret,unique" json:"model"`
+	APISecret EncryptedString `gorm:"size:500;index:idx_ai_model_model_secret,unique" json:"-"`
+}
+
 type GeminiModel struct {
 	AIModel   `mapper:"inherit"`
 	Name      string          `gorm:"size:255;not null" json:"name"`
@@ -66,6 +82,17 @@ func (d *OpenAIModel) GetAPIKey() string {
 	return string(d.APISecret)
 }
 
+func (d *AnthropicModel) GetAPIKey() string {
+	if config.IsDevelopment() && config.Development().AnthropicKey != "" {
+		return config.Development().AnthropicKey
+	}
+	return string(d.APISecret)
+}
+
+func (d *LocalModel) GetAPIKey() string {
+	return string(d.APISecret)
+}
+
 func (d *DeepseekModel) UpdateFromModel(source ModelInterface) {
 	if src, ok := source.(*DeepseekModel); ok {
 		copyFields(d, src)
@@ -82,3 +109,15 @@ func (d *OpenAIModel) UpdateFromModel(source ModelInterface) {
 		copyFields(d, src)
 	}
 }
+
+func (d *LocalModel) UpdateFromModel(source ModelInterface) {
+	if src, ok := source.(*LocalModel); ok {
+		copyFields(d, src)
+	}
+}
+
+func (d *AnthropicModel) UpdateFromModel(source ModelInterface) {
+	if src, ok := source.(*AnthropicModel); ok {
+		copyFields(d, src)
+	}
+}
@@ -1,8 +1,8 @@
 package models
 
 import (
-	"NotificationManagement/types/ollama"
-	"google.golang.org/genai"
+	"encoding/json"
+
 	"gorm.io/gorm"
 )
 
@@ -14,18 +14,40 @@ type CurlRequest struct {
 	Body             string              `gorm:"type:text" json:"body"`
 	RawCurl          string              `gorm:"type:text" json:"rawCurl"`
 	ResponseType     string              `gorm:"type:varchar(10)" json:"responseType"`
+	Extractor        string              `gorm:"type:text" json:"extractor,omitempty"`
+	ConnectTimeout   *uint               `json:"connectTimeout,omitempty"` // in seconds
+	Timeout          *uint               `json:"timeout,omitempty"`        // in seconds
+	MaxRetries       *uint               `json:"maxRetries,omitempty"`
+	MaxResponseSize  *int64              `json:"maxResponseSize,omitempty"` // in bytes
+	AllowedStatuses  string              `gorm:"type:varchar(100)" json:"allowedStatuses,omitempty"`
+	ContentBudget    *uint               `json:"contentBudget,omitempty"` // in bytes
+	SnapshotLimit    *uint               `json:"snapshotLimit,omitempty"`
+	IncludeDiff      bool                `gorm:"default:false" json:"includeDiff"`
+	Auth             RequestAuth         `gorm:"embedded;embeddedPrefix:auth_" json:"auth" mapper:"inherit"`
+	Pagination       RequestPagination   `gorm:"embedded;embeddedPrefix:page_" json:"pagination" mapper:"inherit"`
+	GraphQL          RequestGraphQL      `gorm:"embedded;embeddedPrefix:graphql_" json:"graphql" mapper:"inherit"`
+	Transport        RequestTransport    `gorm:"embedded;embeddedPrefix:transport_" json:"transport" mapper:"inherit"`
+	PromptTemplateID *uint               `json:"promptTemplateId,omitempty"`
+	PromptVersion    *uint               `json:"promptVersion,omitempty"` // nil follows the latest version
 	UserID           uint                `json:"user_id"`
 	User             *User               `gorm:"foreignKey:UserID" json:"-"`
 	Reminders        *[]Reminder         `gorm:"foreignKey:RequestID"`
 	Models           *[]RequestAIModel   `gorm:"foreignKey:RequestID"`
 	AdditionalFields *[]AdditionalFields `gorm:"foreignKey:RequestID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"additional_fields"`
+	Steps            *[]RequestStep      `gorm:"foreignKey:RequestID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"steps,omitempty"`
 }
 
+// AdditionalFields is a field the AI has to fill in its answer, see FieldSpec.
+// Values and Fields hold JSON.
 type AdditionalFields struct {
 	ID           uint   `gorm:"primaryKey" json:"id"`
 	PropertyName string `gorm:"type:varchar(100)" json:"property_name"`
 	Type         string `gorm:"type:varchar(10)" json:"type"`
 	Description  string `gorm:"type:text" json:"description,omitempty"`
+	Values       string `gorm:"column:allowed_values;type:text" json:"values,omitempty"`
+	ItemType     string `gorm:"type:varchar(10)" json:"item_type,omitempty"`
+	Required     *bool  `json:"required,omitempty"`
+	Fields       string `gorm:"type:text" json:"fields,omitempty"`
 	RequestID    uint   `json:"request_id"`
 }
 
@@ -35,42 +57,14 @@ func (c *CurlRequest) UpdateFromModel(source ModelInterface) {
 	}
 }
 
-func (c *CurlRequest) GetGenaiSchemaProperties() map[string]*genai.Schema {
-	properties := make(map[string]*genai.Schema)
-	for _, field := range *c.AdditionalFields {
-		var schemaType genai.Type
-		switch field.Type {
-		case "number":
-			schemaType = genai.TypeNumber
-		case "boolean":
-			schemaType = genai.TypeBoolean
-		default:
-			schemaType = genai.TypeString
-		}
-		properties[field.PropertyName] = &genai.Schema{
-			Type:        schemaType,
-			Description: field.Description,
-		}
+// GetHeaders decodes the stored JSON header map. An empty column yields an empty map.
+func (c *CurlRequest) GetHeaders() (map[string]string, error) {
+	headers := map[string]string{}
+	if c.Headers == "" || c.Headers == "null" {
+		return headers, nil
 	}
-	return properties
-}
-
-func (c *CurlRequest) GetOllamaSchemaProperties() map[string]ollama.FormatProperty {
-	properties := make(map[string]ollama.FormatProperty)
-	for _, field := range *c.AdditionalFields {
-		var ollamaType string
-		switch field.Type {
-		case "number":
-			ollamaType = "number"
-		case "boolean":
-			ollamaType = "boolean"
-		default:
-			ollamaType = "string"
-		}
-		properties[field.PropertyName] = ollama.FormatProperty{
-			Type:        ollamaType,
-			Description: field.Description,
-		}
+	if err := json.Unmarshal([]byte(c.Headers), &headers); err != nil {
+		return nil, err
 	}
-	return properties
+	return headers, nil
 }
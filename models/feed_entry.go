@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// FeedEntry remembers a feed item GUID that a reminder has already reported.
+type FeedEntry struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	ReminderID uint      `gorm:"not null;uniqueIndex:idx_feed_entry_reminder_guid" json:"reminder_id"`
+	GUID       string    `gorm:"size:512;not null;uniqueIndex:idx_feed_entry_reminder_guid" json:"guid"`
+	SeenAt     time.Time `json:"seen_at"`
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"NotificationManagement/utils/schema"
+	"encoding/json"
+)
+
+const (
+	FieldNumber   = "number"
+	FieldBoolean  = "boolean"
+	FieldText     = "text"
+	FieldEnum     = "enum"
+	FieldDate     = "date"
+	FieldDateTime = "datetime"
+	FieldArray    = "array"
+	FieldObject   = "object"
+)
+
+// FieldSpec defines a field of the AI's answer. Enums list their Values,
+// arrays hold items of a scalar ItemType (enum items use Values too) and
+// objects hold nested Fields. Fields are required unless Required is false.
+type FieldSpec struct {
+	PropertyName string      `json:"property_name"`
+	Type         string      `json:"type"`
+	Description  string      `json:"description,omitempty"`
+	Values       []string    `json:"values,omitempty"`
+	ItemType     string      `json:"item_type,omitempty"`
+	Required     *bool       `json:"required,omitempty"`
+	Fields       []FieldSpec `json:"fields,omitempty"`
+}
+
+func (f *FieldSpec) IsRequired() bool {
+	return f.Required == nil || *f.Required
+}
+
+// Spec decodes the stored field.
+func (f *AdditionalFields) Spec() (FieldSpec, error) {
+	spec := FieldSpec{
+		PropertyName: f.PropertyName,
+		Type:         f.Type,
+		Description:  f.Description,
+		ItemType:     f.ItemType,
+		Required:     f.Required,
+	}
+	if f.Values != "" {
+		if err := json.Unmarshal([]byte(f.Values), &spec.Values); err != nil {
+			return FieldSpec{}, err
+		}
+	}
+	if f.Fields != "" {
+		if err := json.Unmarshal([]byte(f.Fields), &spec.Fields); err != nil {
+			return FieldSpec{}, err
+		}
+	}
+	return spec, nil
+}
+
+// Schema describes the value of the field.
+func (f *FieldSpec) Schema() *schema.Schema {
+	switch f.Type {
+	case FieldArray:
+		item := FieldSpec{Type: f.ItemType, Values: f.Values}
+		return &schema.Schema{Type: schema.TypeArray, Description: f.Description, Items: item.Schema()}
+	case FieldObject:
+		object := &schema.Schema{Type: schema.TypeObject, Description: f.Description}
+		for i := range f.Fields {
+			object.Add(f.Fields[i].PropertyName, f.Fields[i].Schema(), f.Fields[i].IsRequired())
+		}
+		return object
+	}
+	return scalarSchema(f.Type, f.Description, f.Values)
+}
+
+func scalarSchema(fieldType, description string, values []string) *schema.Schema {
+	switch fieldType {
+	case FieldNumber:
+		return &schema.Schema{Type: schema.TypeNumber, Description: description}
+	case FieldBoolean:
+		return &schema.Schema{Type: schema.TypeBoolean, Description: description}
+	case FieldEnum:
+		return &schema.Schema{Type: schema.TypeString, Description: description, Enum: values}
+	case FieldDate:
+		// The hint keeps the format for providers that ignore "format".
+		return &schema.Schema{Type: schema.TypeString, Description: withHint(description, "YYYY-MM-DD"), Format: schema.FormatDate}
+	case FieldDateTime:
+		return &schema.Schema{Type: schema.TypeString, Description: withHint(description, "RFC 3339 date and time"), Format: schema.FormatDateTime}
+	}
+	return &schema.Schema{Type: schema.TypeString, Description: description}
+}
+
+func withHint(description, hint string) string {
+	if description == "" {
+		return hint
+	}
+	return description + " (" + hint + ")"
+}
+
+// ResponseSchema is the object every AI provider is asked to answer with:
+// the IsCorrect verdict followed by the request's additional fields.
+func (c *CurlRequest) ResponseSchema() (*schema.Schema, error) {
+	root := &schema.Schema{Type: schema.TypeObject}
+	root.Add("IsCorrect", &schema.Schema{
+		Type:        schema.TypeBoolean,
+		Description: "Whether the statement in the question holds for the given content",
+	}, true)
+	if c.AdditionalFields == nil {
+		return root, nil
+	}
+	for i := range *c.AdditionalFields {
+		spec, err := (*c.AdditionalFields)[i].Spec()
+		if err != nil {
+			return nil, err
+		}
+		root.Add(spec.PropertyName, spec.Schema(), spec.IsRequired())
+	}
+	return root, nil
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+const (
+	HostRuleAllow = "allow"
+	HostRuleDeny  = "deny"
+)
+
+// HostRule allows or denies a destination for every user holding Role. The
+// pattern is a host name, a "*.example.com" wildcard or an IP/CIDR range.
+type HostRule struct {
+	gorm.Model
+	Role    string `gorm:"size:100;not null;index" json:"role"`
+	Pattern string `gorm:"size:255;not null" json:"pattern"`
+	Action  string `gorm:"size:10;not null" json:"action"`
+}
+
+func (h *HostRule) UpdateFromModel(source ModelInterface) {
+	if src, ok := source.(*HostRule); ok {
+		copyFields(h, src)
+	}
+}
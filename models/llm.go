@@ -10,6 +10,9 @@ type RequestAIModel struct {
 	IsActive  bool     `gorm:"default:true"`
 	AiModelID uint     `gorm:"index:idx_request_ai_model,unique"`
 	AiModel   *AIModel `gorm:"foreignKey:AiModelID"`
+	Weight    float64  `gorm:"default:1"`     // vote weight for the weighted reminder strategy
+	Priority  int      `gorm:"default:0"`     // models are tried in ascending priority
+	Fallback  bool     `gorm:"default:false"` // only asked when an earlier model fails with a retryable error
 	//Parameters   JSON   `gorm:"type:jsonb"`
 }
 
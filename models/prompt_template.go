@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PromptTemplate is a named prompt owned by a user. Its content lives in
+// versions that are never changed once written, so sources pinned to a
+// version keep asking the same question while the template evolves.
+type PromptTemplate struct {
+	gorm.Model
+	UserID        uint                     `gorm:"not null;index:idx_user_prompt_template_name,unique" json:"user_id"`
+	User          *User                    `gorm:"foreignKey:UserID" json:"-"`
+	Name          string                   `gorm:"size:100;not null;index:idx_user_prompt_template_name,unique" json:"name"`
+	LatestVersion uint                     `gorm:"not null" json:"latest_version" mapper:"ignore"`
+	Versions      *[]PromptTemplateVersion `gorm:"foreignKey:TemplateID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"versions,omitempty" mapper:"ignore"`
+}
+
+// PromptTemplateVersion holds the Go template text of the system and user
+// parts of a prompt, see utils/prompt.
+type PromptTemplateVersion struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	TemplateID uint      `gorm:"not null;index:idx_prompt_template_version,unique" json:"template_id"`
+	Version    uint      `gorm:"not null;index:idx_prompt_template_version,unique" json:"version"`
+	System     string    `gorm:"column:system_prompt;type:text" json:"system,omitempty"`
+	User       string    `gorm:"column:user_prompt;type:text;not null" json:"user"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (p *PromptTemplate) UpdateFromModel(source ModelInterface) {
+	if src, ok := source.(*PromptTemplate); ok {
+		copyFields(p, src)
+	}
+}
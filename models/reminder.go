@@ -7,6 +7,23 @@ import (
 	"gorm.io/gorm"
 )
 
+// Rule modes select how a reminder's Rule is combined with the AI. Without a
+// mode only the AI decides.
+const (
+	RuleModeRules  = "rules"  // the rule alone decides, the AI isn't called
+	RuleModeGate   = "gate"   // the AI is only called when the rule matches
+	RuleModeOutput = "output" // the rule decides based on the AI's answer
+)
+
+// Strategies decide how the verdicts of a request's models are combined. Models
+// that fail abstain. Without a strategy the first positive verdict wins.
+const (
+	StrategyFirstPositive = "first_positive" // notify on the first model that says yes
+	StrategyMajority      = "majority"       // more than half of the answering models say yes
+	StrategyUnanimous     = "unanimous"      // every answering model says yes
+	StrategyWeighted      = "weighted"       // yes votes carry more than half of the answering models' weight
+)
+
 type Reminder struct {
 	gorm.Model
 	RequestID       uint
@@ -19,6 +36,14 @@ type Reminder struct {
 	AfterEvery      uint         `gorm:"type:int;not null"`
 	TaskID          string       `gorm:"type:text"`
 	Upto            *time.Time   `gorm:"index"`
+	SkipIfUnchanged bool         `gorm:"default:false"`
+	Rule            string       `gorm:"type:text"`
+	RuleMode        string       `gorm:"size:10"`
+	Strategy        string       `gorm:"size:20"`
+	// What the last successful run saw of the source, see CurlService.CheckForChanges.
+	ContentHash  string `gorm:"type:varchar(64)" mapper:"ignore"`
+	ETag         string `gorm:"column:etag;type:text" mapper:"ignore"`
+	LastModified string `gorm:"type:varchar(64)" mapper:"ignore"`
 }
 
 func (r *Reminder) UpdateFromModel(source ModelInterface) {
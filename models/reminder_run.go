@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ReminderRunSucceeded = "succeeded"
+	ReminderRunFailed    = "failed"
+	ReminderRunSkipped   = "skipped"
+)
+
+// ReminderRun records the outcome of a single reminder execution.
+type ReminderRun struct {
+	gorm.Model
+	ReminderID   uint              `gorm:"index;not null" json:"reminder_id"`
+	Reminder     *Reminder         `gorm:"foreignKey:ReminderID" json:"-"`
+	StartedAt    time.Time         `json:"started_at"`
+	FinishedAt   time.Time         `json:"finished_at"`
+	Status       string            `gorm:"size:20;not null" json:"status"`
+	ErrorType    string            `gorm:"size:50" json:"error_type,omitempty"`
+	ErrorMessage string            `gorm:"type:text" json:"error_message,omitempty"`
+	StatusCode   int               `json:"status_code,omitempty"`
+	Attempts     int               `json:"attempts,omitempty"`
+	Verdicts     []ReminderVerdict `gorm:"foreignKey:RunID;constraint:OnDelete:CASCADE" json:"verdicts,omitempty" mapper:"ignore"`
+}
+
+// ReminderVerdict is the answer of one model during a run. Answer holds the
+// model's JSON answer, Error why it has none. When a fallback model answered,
+// AiModelID is that model and FallbackFrom the model it stood in for.
+type ReminderVerdict struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	RunID        uint      `gorm:"index;not null" json:"run_id"`
+	AiModelID    uint      `gorm:"not null" json:"ai_model_id"`
+	FallbackFrom *uint     `json:"fallback_from,omitempty"`
+	IsCorrect    bool      `json:"is_correct"`
+	Weight       float64   `json:"weight"`
+	Answer       string    `gorm:"type:text" json:"answer,omitempty"`
+	Error        string    `gorm:"type:text" json:"error,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (r *ReminderRun) UpdateFromModel(source ModelInterface) {
+	if src, ok := source.(*ReminderRun); ok {
+		copyFields(r, src)
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+const (
+	AuthBasic             = "basic"
+	AuthClientCredentials = "client_credentials"
+	AuthRefreshToken      = "refresh_token"
+)
+
+// RequestAuth is how a source authenticates against the API it monitors. The
+// secret fields are stored encrypted, never serialised, and may hold
+// {{secret "name"}} placeholders instead of the values themselves.
+type RequestAuth struct {
+	Type         string          `gorm:"type:varchar(20)" json:"type,omitempty"`
+	TokenURL     string          `gorm:"type:text" json:"tokenUrl,omitempty"`
+	ClientID     string          `gorm:"type:varchar(255)" json:"clientId,omitempty"`
+	ClientSecret EncryptedString `gorm:"type:text" json:"-"`
+	Username     string          `gorm:"type:varchar(255)" json:"username,omitempty"`
+	Password     EncryptedString `gorm:"type:text" json:"-"`
+	RefreshToken EncryptedString `gorm:"type:text" json:"-"`
+	Scopes       string          `gorm:"type:text" json:"scopes,omitempty"`
+}
+
+// IsOAuth reports whether requests need an access token from TokenURL.
+func (a *RequestAuth) IsOAuth() bool {
+	return a.Type == AuthClientCredentials || a.Type == AuthRefreshToken
+}
+
+// KeepSecretsFrom fills the secrets left empty in an update with the stored
+// ones, since clients never get them back to send again.
+func (a *RequestAuth) KeepSecretsFrom(stored *RequestAuth) {
+	if a.Type != stored.Type {
+		return
+	}
+	if a.ClientSecret == "" {
+		a.ClientSecret = stored.ClientSecret
+	}
+	if a.Password == "" {
+		a.Password = stored.Password
+	}
+	if a.RefreshToken == "" {
+		a.RefreshToken = stored.RefreshToken
+	}
+}
@@ -0,0 +1,17 @@
+package models
+
+// RequestGraphQL turns a source into a GraphQL query against its URL. The
+// request is sent as a JSON POST and only the response's data reaches the AI.
+type RequestGraphQL struct {
+	Query         string `gorm:"type:text" json:"query,omitempty"`
+	Variables     string `gorm:"type:text" json:"variables,omitempty"`
+	OperationName string `gorm:"type:varchar(255)" json:"operationName,omitempty"`
+	// AllowPartial passes data on when the response also carries errors
+	// instead of failing the fetch.
+	AllowPartial bool `gorm:"default:false" json:"allowPartial,omitempty"`
+}
+
+// IsSet reports whether the source is a GraphQL query.
+func (g *RequestGraphQL) IsSet() bool {
+	return g.Query != ""
+}
@@ -0,0 +1,23 @@
+package models
+
+const (
+	PaginationLink   = "link"
+	PaginationCursor = "cursor"
+	PaginationPage   = "page"
+	PaginationOffset = "offset"
+)
+
+// RequestPagination tells the fetcher how to follow a paginated JSON source.
+// Items found at ItemsPath on every page are merged into one array.
+//
+//   - link: follow the rel="next" URL of the Link header
+//   - cursor: read the next cursor (or next page URL) at CursorPath and send it as Param
+//   - page: increment the Param page number, starting from the one in the URL or 1
+//   - offset: advance the Param offset by the number of items received, starting from the one in the URL or 0
+type RequestPagination struct {
+	Mode       string `gorm:"type:varchar(10)" json:"mode,omitempty"`
+	ItemsPath  string `gorm:"type:text" json:"itemsPath,omitempty"`
+	CursorPath string `gorm:"type:text" json:"cursorPath,omitempty"`
+	Param      string `gorm:"type:varchar(100)" json:"param,omitempty"`
+	MaxPages   *uint  `json:"maxPages,omitempty"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"encoding/json"
+)
+
+const (
+	CaptureFromJSON   = "json"
+	CaptureFromHeader = "header"
+	CaptureFromCookie = "cookie"
+	CaptureFromRegex  = "regex"
+)
+
+// RequestStep is a request sent before its CurlRequest's own request, such as
+// a login POST or a token exchange. Steps run in Position order and share one
+// cookie jar; values captured from a step's response are available to later
+// steps and to the source request as {{var "name"}}.
+type RequestStep struct {
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	RequestID uint   `gorm:"index" json:"request_id"`
+	Position  int    `gorm:"not null" json:"position"`
+	URL       string `gorm:"type:text" json:"url"`
+	Method    string `gorm:"type:varchar(10)" json:"method"`
+	Headers   string `gorm:"type:text" json:"headers"`
+	Body      string `gorm:"type:text" json:"body"`
+	RawCurl   string `gorm:"type:text" json:"rawCurl"`
+	Captures  string `gorm:"type:text" json:"captures"`
+}
+
+// StepCapture takes one value out of a step's response: a JSONPath into the
+// body, a response header, a cookie set on the chain, or the first group of a
+// regular expression over the body.
+type StepCapture struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+	Expr string `json:"expr"`
+}
+
+// GetCaptures decodes the stored JSON capture list.
+func (s *RequestStep) GetCaptures() ([]StepCapture, error) {
+	var captures []StepCapture
+	if s.Captures == "" || s.Captures == "null" {
+		return captures, nil
+	}
+	if err := json.Unmarshal([]byte(s.Captures), &captures); err != nil {
+		return nil, err
+	}
+	return captures, nil
+}
+
+// ToCurlRequest turns the step into a request owned by source so it is
+// resolved and fetched with the source's owner, secrets and limits.
+func (s *RequestStep) ToCurlRequest(source *CurlRequest) *CurlRequest {
+	req := *source
+	req.URL = s.URL
+	req.Method = s.Method
+	req.Headers = s.Headers
+	req.Body = s.Body
+	req.RawCurl = s.RawCurl
+	req.GraphQL = RequestGraphQL{}
+	return &req
+}
@@ -0,0 +1,29 @@
+package models
+
+// RequestTransport configures the connection to a source: an HTTP or SOCKS5
+// proxy, the CA bundle that replaces the system roots, and a client
+// certificate for mutual TLS. Secrets are stored encrypted and never
+// serialised.
+type RequestTransport struct {
+	ProxyURL      string          `gorm:"type:text" json:"proxyUrl,omitempty"`
+	ProxyUsername string          `gorm:"type:varchar(255)" json:"proxyUsername,omitempty"`
+	ProxyPassword EncryptedString `gorm:"type:text" json:"-"`
+	CABundle      string          `gorm:"type:text" json:"caBundle,omitempty"`
+	ClientCert    EncryptedString `gorm:"type:text" json:"-"`
+	ClientKey     EncryptedString `gorm:"type:text" json:"-"`
+	// ClearClientCert removes the stored certificate and key on update.
+	ClearClientCert bool `gorm:"-" json:"-"`
+}
+
+// KeepSecretsFrom fills the secrets left empty in an update with the stored
+// ones, since clients never get them back to send again. The client
+// certificate is only dropped when ClearClientCert asks for it.
+func (t *RequestTransport) KeepSecretsFrom(stored *RequestTransport) {
+	if t.ProxyURL != "" && t.ProxyPassword == "" && t.ProxyUsername == stored.ProxyUsername {
+		t.ProxyPassword = stored.ProxyPassword
+	}
+	if t.ClientCert == "" && t.ClientKey == "" && !t.ClearClientCert {
+		t.ClientCert = stored.ClientCert
+		t.ClientKey = stored.ClientKey
+	}
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// ResponseSnapshot keeps a fetched body so later runs can be compared against it.
+type ResponseSnapshot struct {
+	gorm.Model
+	RequestID   uint         `gorm:"index;not null" json:"request_id"`
+	Request     *CurlRequest `gorm:"foreignKey:RequestID" json:"-"`
+	StatusCode  int          `json:"status_code"`
+	ContentHash string       `gorm:"type:varchar(64)" json:"content_hash"`
+	Body        string       `gorm:"type:text" json:"body"`
+}
+
+func (r *ResponseSnapshot) UpdateFromModel(source ModelInterface) {
+	if src, ok := source.(*ResponseSnapshot); ok {
+		copyFields(r, src)
+	}
+}
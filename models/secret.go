@@ -0,0 +1,21 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// Secret is a named value owned by a user that can be referenced from a
+// CurlRequest with {{secret "name"}} instead of being stored in plain text.
+type Secret struct {
+	gorm.Model
+	UserID uint            `gorm:"not null;index:idx_user_secret_name,unique" json:"user_id"`
+	User   *User           `gorm:"foreignKey:UserID" json:"-"`
+	Name   string          `gorm:"size:100;not null;index:idx_user_secret_name,unique" json:"name"`
+	Value  EncryptedString `gorm:"type:text;not null" json:"-"`
+}
+
+func (s *Secret) UpdateFromModel(source ModelInterface) {
+	if src, ok := source.(*Secret); ok {
+		copyFields(s, src)
+	}
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"NotificationManagement/domain"
+	"NotificationManagement/models"
+
+	"gorm.io/gorm"
+)
+
+type AnthropicModelRepositoryImpl struct {
+	domain.Repository[models.AnthropicModel, uint]
+}
+
+func NewAnthropicModelRepository(db *gorm.DB) domain.AnthropicModelRepository {
+	return &AnthropicModelRepositoryImpl{
+		Repository: NewSQLRepository[models.AnthropicModel](db),
+	}
+}
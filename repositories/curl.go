@@ -3,7 +3,11 @@ package repositories
 import (
 	"NotificationManagement/domain"
 	"NotificationManagement/models"
+	"context"
+	"time"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CurlRequestRepositoryImpl struct {
@@ -16,6 +20,17 @@ func NewCurlRequestRepository(db *gorm.DB) domain.CurlRequestRepository {
 	}
 }
 
+// UpdateRefreshToken stores a refresh token rotated by the token endpoint; the
+// previous one usually stops working once a new one is issued.
+func (r *CurlRequestRepositoryImpl) UpdateRefreshToken(ctx context.Context, id uint, refreshToken string) error {
+	err := r.GetDB(ctx).Model(&models.CurlRequest{}).Where("id = ?", id).
+		Update("auth_refresh_token", models.EncryptedString(refreshToken)).Error
+	if err != nil {
+		return handleDbError(err)
+	}
+	return nil
+}
+
 type AdditionalFieldsRepositoryImpl struct {
 	domain.Repository[models.AdditionalFields, uint]
 }
@@ -25,3 +40,77 @@ func NewAdditionalFieldsRepository(db *gorm.DB) domain.AdditionalFieldsRepositor
 		Repository: NewSQLRepository[models.AdditionalFields](db),
 	}
 }
+
+type ResponseSnapshotRepositoryImpl struct {
+	domain.Repository[models.ResponseSnapshot, uint]
+}
+
+func NewResponseSnapshotRepository(db *gorm.DB) domain.ResponseSnapshotRepository {
+	return &ResponseSnapshotRepositoryImpl{
+		Repository: NewSQLRepository[models.ResponseSnapshot](db),
+	}
+}
+
+func (r *ResponseSnapshotRepositoryImpl) GetLatest(ctx context.Context, requestID uint, limit int) ([]models.ResponseSnapshot, error) {
+	var snapshots []models.ResponseSnapshot
+	err := r.GetDB(ctx).Where("request_id = ?", requestID).Order("id DESC").Limit(limit).Find(&snapshots).Error
+	if err != nil {
+		return nil, handleDbError(err)
+	}
+	return snapshots, nil
+}
+
+// Prune permanently removes all but the newest keep snapshots of a request.
+func (r *ResponseSnapshotRepositoryImpl) Prune(ctx context.Context, requestID uint, keep int) error {
+	newest := r.GetDB(ctx).Model(&models.ResponseSnapshot{}).Select("id").Where("request_id = ?", requestID).Order("id DESC").Limit(keep)
+	err := r.GetDB(ctx).Unscoped().Where("request_id = ? AND id NOT IN (?)", requestID, newest).Delete(&models.ResponseSnapshot{}).Error
+	if err != nil {
+		return handleDbError(err)
+	}
+	return nil
+}
+
+type FeedEntryRepositoryImpl struct {
+	domain.Repository[models.FeedEntry, uint]
+}
+
+func NewFeedEntryRepository(db *gorm.DB) domain.FeedEntryRepository {
+	return &FeedEntryRepositoryImpl{
+		Repository: NewSQLRepository[models.FeedEntry](db),
+	}
+}
+
+func (r *FeedEntryRepositoryImpl) GetSeenGUIDs(ctx context.Context, reminderID uint, guids []string) ([]string, error) {
+	var seen []string
+	if len(guids) == 0 {
+		return seen, nil
+	}
+	err := r.GetDB(ctx).Model(&models.FeedEntry{}).Where("reminder_id = ? AND guid IN ?", reminderID, guids).Pluck("guid", &seen).Error
+	if err != nil {
+		return nil, handleDbError(err)
+	}
+	return seen, nil
+}
+
+// ReplaceSeen records the given GUIDs and forgets those that are no longer in
+// the feed, so the table stays as large as the feed itself.
+func (r *FeedEntryRepositoryImpl) ReplaceSeen(ctx context.Context, reminderID uint, added []string, current []string) error {
+	return r.GetDB(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(added) > 0 {
+			now := time.Now()
+			entries := make([]models.FeedEntry, 0, len(added))
+			for _, guid := range added {
+				entries = append(entries, models.FeedEntry{ReminderID: reminderID, GUID: guid, SeenAt: now})
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entries).Error; err != nil {
+				return handleDbError(err)
+			}
+		}
+		if len(current) > 0 {
+			if err := tx.Where("reminder_id = ? AND guid NOT IN ?", reminderID, current).Delete(&models.FeedEntry{}).Error; err != nil {
+				return handleDbError(err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"NotificationManagement/domain"
+	"context"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const hostLimiterPrefix = "host_rate:"
+
+// reserveScript is a token bucket that lets the balance go negative, so each
+// caller reserves a distinct future slot instead of racing for the next one.
+// It returns the wait in milliseconds.
+var reserveScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+if now > ts then
+	tokens = math.min(burst, tokens + (now - ts) * rate)
+	ts = now
+end
+tokens = tokens - 1
+local wait = 0
+if tokens < 0 then
+	wait = math.ceil(-tokens / rate)
+end
+redis.call('HMSET', KEYS[1], 'tokens', tokens, 'ts', ts)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate) + wait + 1000)
+return wait
+`)
+
+type RedisHostLimiter struct {
+	client *redis.Client
+}
+
+func NewHostLimiter(client *redis.Client) domain.HostLimiter {
+	return &RedisHostLimiter{client: client}
+}
+
+func (r *RedisHostLimiter) Reserve(ctx context.Context, host string, perMinute, burst int) (time.Duration, error) {
+	if perMinute <= 0 {
+		return 0, nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	perMillisecond := float64(perMinute) / float64(time.Minute/time.Millisecond)
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	wait, err := reserveScript.Run(r.client.WithContext(ctx), []string{hostLimiterPrefix + host}, perMillisecond, burst, now).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(wait) * time.Millisecond, nil
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"NotificationManagement/domain"
+	"NotificationManagement/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type HostRuleRepositoryImpl struct {
+	domain.Repository[models.HostRule, uint]
+}
+
+func NewHostRuleRepository(db *gorm.DB) domain.HostRuleRepository {
+	return &HostRuleRepositoryImpl{
+		Repository: NewSQLRepository[models.HostRule](db),
+	}
+}
+
+func (r *HostRuleRepositoryImpl) FindByRoles(ctx context.Context, roles []string) ([]models.HostRule, error) {
+	var rules []models.HostRule
+	if len(roles) == 0 {
+		return rules, nil
+	}
+	err := r.GetDB(ctx).Where("role IN ?", roles).Find(&rules).Error
+	if err != nil {
+		return nil, handleDbError(err)
+	}
+	return rules, nil
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"NotificationManagement/domain"
+	"NotificationManagement/models"
+
+	"gorm.io/gorm"
+)
+
+type LocalModelRepositoryImpl struct {
+	domain.Repository[models.LocalModel, uint]
+}
+
+func NewLocalModelRepository(db *gorm.DB) domain.LocalModelRepository {
+	return &LocalModelRepositoryImpl{
+		Repository: NewSQLRepository[models.LocalModel](db),
+	}
+}
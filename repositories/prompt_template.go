@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"NotificationManagement/domain"
+	"NotificationManagement/models"
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PromptTemplateRepositoryImpl struct {
+	domain.Repository[models.PromptTemplate, uint]
+}
+
+func NewPromptTemplateRepository(db *gorm.DB) domain.PromptTemplateRepository {
+	return &PromptTemplateRepositoryImpl{
+		Repository: NewSQLRepository[models.PromptTemplate](db),
+	}
+}
+
+func (r *PromptTemplateRepositoryImpl) GetAllByUser(ctx context.Context, userID uint, limit, offset int) ([]models.PromptTemplate, error) {
+	var templates []models.PromptTemplate
+	err := r.GetDB(ctx).Where("user_id = ?", userID).Order("name").Limit(limit).Offset(offset).Find(&templates).Error
+	if err != nil {
+		return nil, handleDbError(err)
+	}
+	return templates, nil
+}
+
+func (r *PromptTemplateRepositoryImpl) GetVersion(ctx context.Context, templateID, version uint) (*models.PromptTemplateVersion, error) {
+	var v models.PromptTemplateVersion
+	err := r.GetDB(ctx).Where("template_id = ? AND version = ?", templateID, version).First(&v).Error
+	if err != nil {
+		return nil, handleDbError(err)
+	}
+	return &v, nil
+}
+
+// AddVersion stores version as the template's next version and renames the
+// template. The template row is locked so concurrent edits get distinct
+// version numbers.
+func (r *PromptTemplateRepositoryImpl) AddVersion(ctx context.Context, templateID uint, name string, version *models.PromptTemplateVersion) error {
+	return r.GetDB(ctx).Transaction(func(tx *gorm.DB) error {
+		var template models.PromptTemplate
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&template, templateID).Error; err != nil {
+			return handleDbError(err)
+		}
+		version.ID = 0
+		version.TemplateID = templateID
+		version.Version = template.LatestVersion + 1
+		if err := tx.Create(version).Error; err != nil {
+			return handleDbError(err)
+		}
+		err := tx.Model(&template).Updates(map[string]interface{}{"name": name, "latest_version": version.Version}).Error
+		if err != nil {
+			return handleDbError(err)
+		}
+		return nil
+	})
+}
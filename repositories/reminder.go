@@ -3,6 +3,7 @@ package repositories
 import (
 	"NotificationManagement/domain"
 	"NotificationManagement/models"
+	"context"
 
 	"gorm.io/gorm"
 )
@@ -16,3 +17,25 @@ func NewReminderRepository(db *gorm.DB) domain.ReminderRepository {
 		Repository: NewSQLRepository[models.Reminder](db),
 	}
 }
+
+func (r *ReminderRepositoryImpl) UpdateFetchState(ctx context.Context, id uint, contentHash, etag, lastModified string) error {
+	err := r.GetDB(ctx).Model(&models.Reminder{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"content_hash":  contentHash,
+		"etag":          etag,
+		"last_modified": lastModified,
+	}).Error
+	if err != nil {
+		return handleDbError(err)
+	}
+	return nil
+}
+
+type ReminderRunRepositoryImpl struct {
+	domain.Repository[models.ReminderRun, uint]
+}
+
+func NewReminderRunRepository(db *gorm.DB) domain.ReminderRunRepository {
+	return &ReminderRunRepositoryImpl{
+		Repository: NewSQLRepository[models.ReminderRun](db),
+	}
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"NotificationManagement/domain"
+	"NotificationManagement/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type SecretRepositoryImpl struct {
+	domain.Repository[models.Secret, uint]
+}
+
+func NewSecretRepository(db *gorm.DB) domain.SecretRepository {
+	return &SecretRepositoryImpl{
+		Repository: NewSQLRepository[models.Secret](db),
+	}
+}
+
+func (r *SecretRepositoryImpl) FindByUserAndName(ctx context.Context, userID uint, name string) (*models.Secret, error) {
+	var secret models.Secret
+	err := r.GetDB(ctx).Where("user_id = ? AND name = ?", userID, name).First(&secret).Error
+	if err != nil {
+		return nil, handleDbError(err)
+	}
+	return &secret, nil
+}
+
+func (r *SecretRepositoryImpl) GetAllByUser(ctx context.Context, userID uint, limit, offset int) ([]models.Secret, error) {
+	var secrets []models.Secret
+	err := r.GetDB(ctx).Where("user_id = ?", userID).Order("name").Limit(limit).Offset(offset).Find(&secrets).Error
+	if err != nil {
+		return nil, handleDbError(err)
+	}
+	return secrets, nil
+}
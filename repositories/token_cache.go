@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"NotificationManagement/domain"
+	"context"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const tokenCachePrefix = "source_token:"
+
+type RedisTokenCache struct {
+	client *redis.Client
+}
+
+func NewTokenCache(client *redis.Client) domain.TokenCache {
+	return &RedisTokenCache{client: client}
+}
+
+func (r *RedisTokenCache) Get(ctx context.Context, key string) (string, bool, error) {
+	token, err := r.client.WithContext(ctx).Get(tokenCachePrefix + key).Result()
+	if err == redis.Nil {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return token, true, nil
+}
+
+func (r *RedisTokenCache) Set(ctx context.Context, key, token string, ttl time.Duration) error {
+	return r.client.WithContext(ctx).Set(tokenCachePrefix+key, token, ttl).Err()
+}
+
+func (r *RedisTokenCache) Delete(ctx context.Context, key string) error {
+	return r.client.WithContext(ctx).Del(tokenCachePrefix + key).Err()
+}
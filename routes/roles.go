@@ -24,6 +24,30 @@ const (
 	RoleReminderDelete = "reminder_delete"
 )
 
+// Role constants for Secret operations
+const (
+	RoleSecretCreate = "secret_create"
+	RoleSecretRead   = "secret_read"
+	RoleSecretUpdate = "secret_update"
+	RoleSecretDelete = "secret_delete"
+)
+
+// Role constants for prompt template operations
+const (
+	RolePromptTemplateCreate = "prompt_template_create"
+	RolePromptTemplateRead   = "prompt_template_read"
+	RolePromptTemplateUpdate = "prompt_template_update"
+	RolePromptTemplateDelete = "prompt_template_delete"
+)
+
+// Role constants for host rule administration
+const (
+	RoleHostRuleCreate = "host_rule_create"
+	RoleHostRuleRead   = "host_rule_read"
+	RoleHostRuleUpdate = "host_rule_update"
+	RoleHostRuleDelete = "host_rule_delete"
+)
+
 const (
 	// Role constants for Deepseek Model operations
 	RoleAICreate = "ai_model_create"
@@ -11,9 +11,42 @@ func RegisterCurlRoutes(e *echo.Echo, controller domain.CurlController, keycloak
 	cg := e.Group("/api/curl", *keycloakMiddleware)
 
 	cg.POST("", controller.CurlHandler, middleware.RequireRoles(RoleCurlCreate))
+	cg.POST("/test", controller.TestCurlRequest, middleware.RequireRoles(RoleCurlCreate))
+	cg.POST("/import/:format", controller.ImportCurlRequests, middleware.RequireRoles(RoleCurlCreate))
+	cg.GET("/:id/export", controller.ExportCurlRequest, middleware.RequireRoles(RoleCurlRead))
 	cg.GET("/:id", controller.GetCurlRequestByID, middleware.RequireRoles(RoleCurlRead))
 	cg.PUT("/:id", controller.UpdateCurlRequest, middleware.RequireRoles(RoleCurlUpdate))
 	cg.DELETE("/:id", controller.DeleteCurlRequest, middleware.RequireRoles(RoleCurlDelete))
+	cg.POST("/:id/preview", controller.PreviewExtraction, middleware.RequireRoles(RoleCurlRead))
+}
+
+func RegisterSecretRoutes(e *echo.Echo, controller domain.SecretController, keycloakMiddleware *echo.MiddlewareFunc) {
+	sg := e.Group("/api/secret", *keycloakMiddleware)
+
+	sg.POST("", controller.CreateSecret, middleware.RequireRoles(RoleSecretCreate))
+	sg.GET("", controller.GetAllSecrets, middleware.RequireRoles(RoleSecretRead))
+	sg.PUT("/:id", controller.UpdateSecret, middleware.RequireRoles(RoleSecretUpdate))
+	sg.DELETE("/:id", controller.DeleteSecret, middleware.RequireRoles(RoleSecretDelete))
+}
+
+func RegisterPromptTemplateRoutes(e *echo.Echo, controller domain.PromptTemplateController, keycloakMiddleware *echo.MiddlewareFunc) {
+	pg := e.Group("/api/prompt-template", *keycloakMiddleware)
+
+	pg.POST("", controller.CreatePromptTemplate, middleware.RequireRoles(RolePromptTemplateCreate))
+	pg.GET("/:id", controller.GetPromptTemplateByID, middleware.RequireRoles(RolePromptTemplateRead))
+	pg.GET("", controller.GetAllPromptTemplates, middleware.RequireRoles(RolePromptTemplateRead))
+	pg.PUT("/:id", controller.UpdatePromptTemplate, middleware.RequireRoles(RolePromptTemplateUpdate))
+	pg.DELETE("/:id", controller.DeletePromptTemplate, middleware.RequireRoles(RolePromptTemplateDelete))
+}
+
+func RegisterHostRuleRoutes(e *echo.Echo, controller domain.HostRuleController, keycloakMiddleware *echo.MiddlewareFunc) {
+	hg := e.Group("/api/host-rule", *keycloakMiddleware)
+
+	hg.POST("", controller.CreateHostRule, middleware.RequireRoles(RoleHostRuleCreate))
+	hg.GET("/:id", controller.GetHostRuleByID, middleware.RequireRoles(RoleHostRuleRead))
+	hg.GET("", controller.GetAllHostRules, middleware.RequireRoles(RoleHostRuleRead))
+	hg.PUT("/:id", controller.UpdateHostRule, middleware.RequireRoles(RoleHostRuleUpdate))
+	hg.DELETE("/:id", controller.DeleteHostRule, middleware.RequireRoles(RoleHostRuleDelete))
 }
 
 func RegisterLLMRoutes(e *echo.Echo, controller domain.LLMController, keycloakMiddleware *echo.MiddlewareFunc) {
@@ -1,10 +1,14 @@
 CREATE TABLE IF NOT EXISTS public.additional_fields
 (
-    id            bigserial,
-    property_name varchar(100),
-    type          varchar(10),
-    description   text,
-    request_id    bigint,
+    id             bigserial,
+    property_name  varchar(100),
+    type           varchar(10),
+    description    text,
+    allowed_values text,
+    item_type      varchar(10),
+    required       boolean,
+    fields         text,
+    request_id     bigint,
     PRIMARY KEY (id),
     CONSTRAINT fk_curl_requests_additional_fields
         FOREIGN KEY (request_id) REFERENCES public.curl_requests
@@ -14,7 +14,7 @@ CREATE TABLE IF NOT EXISTS public.ai_models
     CONSTRAINT chk_ai_models_model_name
         CHECK ((model_name)::text <> ''::text),
     CONSTRAINT chk_ai_models_type
-        CHECK ((type)::text = ANY ((ARRAY ['local'::character varying, 'openai'::character varying, 'gemini'::character varying, 'deepseek'::character varying])::text[]))
+        CHECK ((type)::text = ANY ((ARRAY ['local'::character varying, 'openai'::character varying, 'gemini'::character varying, 'deepseek'::character varying, 'anthropic'::character varying])::text[]))
 );
 
 CREATE UNIQUE INDEX IF NOT EXISTS idx_ai_model_model_url
@@ -1,16 +1,50 @@
 CREATE TABLE IF NOT EXISTS public.curl_requests
 (
-    id            bigserial,
-    created_at    timestamp with time zone,
-    updated_at    timestamp with time zone,
-    deleted_at    timestamp with time zone,
-    url           text,
-    method        varchar(10),
-    headers       text,
-    body          text,
-    raw_curl      text,
-    response_type varchar(10),
-    user_id       bigint,
+    id                       bigserial,
+    created_at               timestamp with time zone,
+    updated_at               timestamp with time zone,
+    deleted_at               timestamp with time zone,
+    url                      text,
+    method                   varchar(10),
+    headers                  text,
+    body                     text,
+    raw_curl                 text,
+    response_type            varchar(10),
+    extractor                text,
+    connect_timeout          bigint,
+    timeout                  bigint,
+    max_retries              bigint,
+    max_response_size        bigint,
+    allowed_statuses         varchar(100),
+    content_budget           bigint,
+    snapshot_limit           bigint,
+    include_diff             boolean DEFAULT false,
+    auth_type                varchar(20),
+    auth_token_url           text,
+    auth_client_id           varchar(255),
+    auth_client_secret       text,
+    auth_username            varchar(255),
+    auth_password            text,
+    auth_refresh_token       text,
+    auth_scopes              text,
+    page_mode                varchar(10),
+    page_items_path          text,
+    page_cursor_path         text,
+    page_param               varchar(100),
+    page_max_pages           bigint,
+    graphql_query            text,
+    graphql_variables        text,
+    graphql_operation_name   varchar(255),
+    graphql_allow_partial    boolean DEFAULT false,
+    transport_proxy_url      text,
+    transport_proxy_username varchar(255),
+    transport_proxy_password text,
+    transport_ca_bundle      text,
+    transport_client_cert    text,
+    transport_client_key     text,
+    prompt_template_id       bigint,
+    prompt_version           bigint,
+    user_id                  bigint,
     PRIMARY KEY (id),
     CONSTRAINT fk_curl_requests_user
         FOREIGN KEY (user_id) REFERENCES public.users
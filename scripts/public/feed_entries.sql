@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS public.feed_entries
+(
+    id          bigserial,
+    reminder_id bigint       NOT NULL,
+    guid        varchar(512) NOT NULL,
+    seen_at     timestamp with time zone,
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feed_entry_reminder_guid
+    ON public.feed_entries (reminder_id, guid);
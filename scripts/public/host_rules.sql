@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS public.host_rules
+(
+    id         bigserial,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    role       varchar(100) NOT NULL,
+    pattern    varchar(255) NOT NULL,
+    action     varchar(10)  NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_host_rules_role
+    ON public.host_rules (role);
+
+CREATE INDEX IF NOT EXISTS idx_host_rules_deleted_at
+    ON public.host_rules (deleted_at);
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS public.prompt_templates
+(
+    id             bigserial,
+    created_at     timestamp with time zone,
+    updated_at     timestamp with time zone,
+    deleted_at     timestamp with time zone,
+    user_id        bigint       NOT NULL,
+    name           varchar(100) NOT NULL,
+    latest_version bigint       NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_prompt_templates_user
+        FOREIGN KEY (user_id) REFERENCES public.users
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_prompt_template_name
+    ON public.prompt_templates (user_id, name);
+
+CREATE INDEX IF NOT EXISTS idx_prompt_templates_deleted_at
+    ON public.prompt_templates (deleted_at);
+
+CREATE TABLE IF NOT EXISTS public.prompt_template_versions
+(
+    id            bigserial,
+    template_id   bigint NOT NULL,
+    version       bigint NOT NULL,
+    system_prompt text,
+    user_prompt   text   NOT NULL,
+    created_at    timestamp with time zone,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_prompt_templates_versions
+        FOREIGN KEY (template_id) REFERENCES public.prompt_templates
+            ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_prompt_template_version
+    ON public.prompt_template_versions (template_id, version);
@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS public.reminder_runs
+(
+    id            bigserial,
+    created_at    timestamp with time zone,
+    updated_at    timestamp with time zone,
+    deleted_at    timestamp with time zone,
+    reminder_id   bigint      NOT NULL,
+    started_at    timestamp with time zone,
+    finished_at   timestamp with time zone,
+    status        varchar(20) NOT NULL,
+    error_type    varchar(50),
+    error_message text,
+    status_code   bigint,
+    attempts      bigint,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_reminder_runs_reminder
+        FOREIGN KEY (reminder_id) REFERENCES public.reminders
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminder_runs_reminder_id
+    ON public.reminder_runs (reminder_id);
+
+CREATE INDEX IF NOT EXISTS idx_reminder_runs_deleted_at
+    ON public.reminder_runs (deleted_at);
+
+CREATE TABLE IF NOT EXISTS public.reminder_verdicts
+(
+    id            bigserial,
+    run_id        bigint NOT NULL,
+    ai_model_id   bigint NOT NULL,
+    fallback_from bigint,
+    is_correct    boolean,
+    weight        numeric,
+    answer        text,
+    error         text,
+    created_at    timestamp with time zone,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_reminder_runs_verdicts
+        FOREIGN KEY (run_id) REFERENCES public.reminder_runs
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminder_verdicts_run_id
+    ON public.reminder_verdicts (run_id);
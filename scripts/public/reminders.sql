@@ -13,6 +13,13 @@ CREATE TABLE IF NOT EXISTS public.reminders
     after_every       bigint      NOT NULL,
     task_id           text,
     upto              timestamp with time zone,
+    skip_if_unchanged boolean DEFAULT false,
+    rule              text,
+    rule_mode         varchar(10),
+    strategy          varchar(20),
+    content_hash      varchar(64),
+    etag              text,
+    last_modified     varchar(64),
     PRIMARY KEY (id),
     CONSTRAINT fk_curl_requests_reminders
         FOREIGN KEY (request_id) REFERENCES public.curl_requests
@@ -7,6 +7,9 @@ CREATE TABLE IF NOT EXISTS public.request_ai_models
     request_id  bigint,
     is_active   boolean DEFAULT TRUE,
     ai_model_id bigint,
+    weight      numeric DEFAULT 1,
+    priority    bigint  DEFAULT 0,
+    fallback    boolean DEFAULT FALSE,
     PRIMARY KEY (id),
     CONSTRAINT fk_request_ai_models_ai_model
         FOREIGN KEY (ai_model_id) REFERENCES public.ai_models,
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS public.request_steps
+(
+    id         bigserial,
+    request_id bigint,
+    position   bigint      NOT NULL,
+    url        text,
+    method     varchar(10),
+    headers    text,
+    body       text,
+    raw_curl   text,
+    captures   text,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_curl_requests_steps
+        FOREIGN KEY (request_id) REFERENCES public.curl_requests
+            ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_steps_request_id
+    ON public.request_steps (request_id);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS public.response_snapshots
+(
+    id           bigserial,
+    created_at   timestamp with time zone,
+    updated_at   timestamp with time zone,
+    deleted_at   timestamp with time zone,
+    request_id   bigint NOT NULL,
+    status_code  bigint,
+    content_hash varchar(64),
+    body         text,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_response_snapshots_request
+        FOREIGN KEY (request_id) REFERENCES public.curl_requests
+);
+
+CREATE INDEX IF NOT EXISTS idx_response_snapshots_request_id
+    ON public.response_snapshots (request_id);
+
+CREATE INDEX IF NOT EXISTS idx_response_snapshots_deleted_at
+    ON public.response_snapshots (deleted_at);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS public.secrets
+(
+    id         bigserial,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    user_id    bigint       NOT NULL,
+    name       varchar(100) NOT NULL,
+    value      text         NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_secrets_user
+        FOREIGN KEY (user_id) REFERENCES public.users
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_secret_name
+    ON public.secrets (user_id, name);
+
+CREATE INDEX IF NOT EXISTS idx_secrets_deleted_at
+    ON public.secrets (deleted_at);
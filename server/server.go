@@ -22,7 +22,7 @@ func NewEcho(db *gorm.DB) *echo.Echo {
 	return e
 }
 
-func RegisterRoutes(e *echo.Echo, curlController domain.CurlController, llmController domain.LLMController, reminderController domain.ReminderController, aiController domain.AIRequestController, userController domain.UserController, notificationController *controllers.NotificationController, userService domain.UserService, telegramController domain.TelegramController) {
+func RegisterRoutes(e *echo.Echo, curlController domain.CurlController, llmController domain.LLMController, reminderController domain.ReminderController, aiController domain.AIRequestController, userController domain.UserController, notificationController *controllers.NotificationController, userService domain.UserService, telegramController domain.TelegramController, secretController domain.SecretController, hostRuleController domain.HostRuleController, promptTemplateController domain.PromptTemplateController) {
 	keycloakMiddleware := middleware.KeycloakMiddleware(userService)
 	routes.RegisterCurlRoutes(e, curlController, &keycloakMiddleware)
 	routes.RegisterLLMRoutes(e, llmController, &keycloakMiddleware)
@@ -30,6 +30,9 @@ func RegisterRoutes(e *echo.Echo, curlController domain.CurlController, llmContr
 	routes.RegisterAIRoutes(e, aiController, &keycloakMiddleware)
 	routes.RegisterUserRoutes(e, userController, &keycloakMiddleware)
 	routes.RegisterTelegramRoutes(e, telegramController, &keycloakMiddleware)
+	routes.RegisterSecretRoutes(e, secretController, &keycloakMiddleware)
+	routes.RegisterHostRuleRoutes(e, hostRuleController, &keycloakMiddleware)
+	routes.RegisterPromptTemplateRoutes(e, promptTemplateController, &keycloakMiddleware)
 	routes.RegisterNotificationRoutes(e, notificationController, &keycloakMiddleware)
 }
 
@@ -53,6 +56,7 @@ var Module = fx.Options(
 		conn.NewDB,
 		conn.NewAsynq,
 		conn.NewAsynqInspector,
+		conn.Redis,
 		notifier.NewEmailNotifier,
 		notifier.NewSMSNotifier,
 		notifier.NewTelegramNotifier,
@@ -65,17 +69,30 @@ var Module = fx.Options(
 		controllers.NewUserController,
 		controllers.NewNotificationController,
 		controllers.NewTelegramController,
+		controllers.NewSecretController,
+		controllers.NewHostRuleController,
+		controllers.NewPromptTemplateController,
 
 		repositories.NewAIModelRepository,
 		repositories.NewCurlRequestRepository,
 		repositories.NewAdditionalFieldsRepository,
+		repositories.NewResponseSnapshotRepository,
+		repositories.NewFeedEntryRepository,
 		repositories.NewDeepseekModelRepository,
 		repositories.NewGeminiRepository,
 		repositories.NewLLMRepository,
 		repositories.NewReminderRepository,
+		repositories.NewReminderRunRepository,
 		repositories.NewUserRepository,
 		repositories.NewTelegramRepository,
 		repositories.NewOpenAIModelRepository,
+		repositories.NewLocalModelRepository,
+		repositories.NewAnthropicModelRepository,
+		repositories.NewSecretRepository,
+		repositories.NewHostRuleRepository,
+		repositories.NewPromptTemplateRepository,
+		repositories.NewTokenCache,
+		repositories.NewHostLimiter,
 
 		services.NewAIModelService,
 		services.NewAsynqService,
@@ -83,8 +100,13 @@ var Module = fx.Options(
 		services.NewDeepseekModelService,
 		services.NewGeminiService,
 		services.NewOpenAIService,
+		services.NewLocalService,
+		services.NewAnthropicService,
 		services.NewLLMService,
 		services.NewReminderService,
+		services.NewSecretService,
+		services.NewHostRuleService,
+		services.NewPromptTemplateService,
 		services.NewUserService,
 		services.NewAIDispatcher,
 		services.NewTelegramAPI, // TODO : Need to remove from here.Manage By Worker
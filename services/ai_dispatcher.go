@@ -2,9 +2,19 @@ package services
 
 import (
 	"NotificationManagement/domain"
+	"NotificationManagement/logger"
 	"NotificationManagement/models"
+	"NotificationManagement/types"
 	"NotificationManagement/utils/errutil"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/sashabaranov/go-openai"
+	"google.golang.org/genai"
 )
 
 type AiDispatcherImpl struct {
@@ -12,12 +22,14 @@ type AiDispatcherImpl struct {
 	aiModel  domain.AIModelService
 }
 
-func NewAIDispatcher(geminiService domain.GeminiService, deepseekService domain.DeepseekService, openaiService domain.OpenAIService, ai domain.AIModelService) domain.AiDispatcher {
+func NewAIDispatcher(geminiService domain.GeminiService, deepseekService domain.DeepseekService, openaiService domain.OpenAIService, localService domain.LocalService, anthropicService domain.AnthropicService, ai domain.AIModelService) domain.AiDispatcher {
 	return &AiDispatcherImpl{
 		services: &[]domain.DispatchableAIService{
 			geminiService,
 			deepseekService,
 			openaiService,
+			localService,
+			anthropicService,
 		},
 		aiModel: ai,
 	}
@@ -32,6 +44,59 @@ func (a *AiDispatcherImpl) RequestProcessor(c context.Context, m *models.AIModel
 	return nil, errutil.NewAppError(errutil.ErrFeatureNotAvailable, errutil.ErrInvalidFeature)
 }
 
+// RequestChain asks the models of chain in order until one answers. It only
+// moves on to the next model when the previous one failed with a retryable
+// provider error, and returns the model that answered, or the last one tried.
+func (a *AiDispatcherImpl) RequestChain(c context.Context, chain []models.RequestAIModel, requestId uint) (map[string]interface{}, *models.RequestAIModel, error) {
+	var err error
+	for i := range chain {
+		var answer map[string]interface{}
+		answer, err = a.RequestProcessor(c, chain[i].AiModel, requestId)
+		if err == nil || i == len(chain)-1 || !isRetryableAIError(err) || c.Err() != nil {
+			return answer, &chain[i], err
+		}
+		logger.Warn("AI model failed, falling back to the next model", "request_id", requestId, "ai_model_id", chain[i].AiModelID, "next_ai_model_id", chain[i+1].AiModelID, "error", err)
+	}
+	return nil, nil, fmt.Errorf("no AI model to ask for request %d", requestId)
+}
+
+// isRetryableAIError reports whether another model may succeed where the
+// provider failed: rate limits, server errors, timeouts and answers that
+// aren't valid JSON. Errors fetching the source are never retryable.
+func isRetryableAIError(err error) bool {
+	var fetchErr *types.FetchError
+	if errors.As(err, &fetchErr) {
+		return false
+	}
+
+	var status int
+	var statusErr *types.AIStatusError
+	var genaiErr genai.APIError
+	var openaiErr *openai.APIError
+	var requestErr *openai.RequestError
+	switch {
+	case errors.As(err, &statusErr):
+		status = statusErr.StatusCode
+	case errors.As(err, &genaiErr):
+		status = genaiErr.Code
+	case errors.As(err, &openaiErr):
+		status = openaiErr.HTTPStatusCode
+	case errors.As(err, &requestErr):
+		status = requestErr.HTTPStatusCode
+	}
+	if status != 0 {
+		return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return true
+	}
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return errors.Is(err, types.ErrInvalidAIOutput) || errors.As(err, &syntaxErr) || errors.As(err, &typeErr)
+}
+
 func (a *AiDispatcherImpl) ProcessCreateModel(ctx context.Context, model models.AIModelInterface) error {
 	for _, service := range *a.services {
 		if service.GetModelType() == model.GetType() {
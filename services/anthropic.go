@@ -0,0 +1,185 @@
+package services
+
+import (
+	"NotificationManagement/domain"
+	"NotificationManagement/models"
+	"NotificationManagement/repositories"
+	"NotificationManagement/types"
+	"NotificationManagement/types/anthropic"
+	"NotificationManagement/utils/errutil"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	anthropicBaseURL    = "https://api.anthropic.com"
+	anthropicVersion    = "2023-06-01"
+	anthropicMaxTokens  = 4096
+	anthropicAnswerTool = "record_answer"
+)
+
+type AnthropicServiceImpl struct {
+	domain.CommonService[models.AnthropicModel]
+	CurlService domain.CurlService
+	Prompts     domain.PromptTemplateService
+}
+
+func NewAnthropicService(repo domain.AnthropicModelRepository, curl domain.CurlService, prompts domain.PromptTemplateService) domain.AnthropicService {
+	service := &AnthropicServiceImpl{
+		CurlService: curl,
+		Prompts:     prompts,
+	}
+	service.CommonService = NewCommonService(repo, service)
+	return service
+}
+
+func (s *AnthropicServiceImpl) ProcessContext(ctx context.Context) context.Context {
+	if txContext, ok := repositories.GetTxContext(ctx); ok {
+		filters := append(txContext.Filter, repositories.NewFilter("type", "=", s.GetModelType()))
+		txContext.Filter = filters
+	}
+	return ctx
+}
+
+func (s *AnthropicServiceImpl) MakeAIRequest(c context.Context, m *models.AIModel, requestId uint) (interface{}, error) {
+	curl, err := s.CurlService.GetModelById(c, requestId, nil)
+	if err != nil {
+		return nil, err
+	}
+	curlResponse, err := s.CurlService.ProcessCurlRequest(c, curl)
+	if err != nil {
+		return nil, err
+	}
+	model, err := s.GetModelById(c, m.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+	prompt, err := s.Prompts.BuildPrompt(c, curl, curlResponse)
+	if err != nil {
+		return nil, err
+	}
+	return anthropicCall(c, model, prompt, curl)
+}
+
+func (s *AnthropicServiceImpl) GetAIJsonResponse(c context.Context, m *models.AIModel, requestId uint) (map[string]interface{}, error) {
+	request, err := s.MakeAIRequest(c, m, requestId)
+	if err != nil {
+		return nil, err
+	}
+	resp, _ := request.(*anthropic.Response)
+	input, ok := resp.ToolInput(anthropicAnswerTool)
+	if !ok {
+		return nil, errutil.NewAppError(errutil.ErrExternalServiceError, fmt.Errorf("%w: anthropic answered without calling %s (stop reason %q)", types.ErrInvalidAIOutput, anthropicAnswerTool, resp.StopReason))
+	}
+	var aiResp map[string]interface{}
+	if err := json.Unmarshal(input, &aiResp); err != nil {
+		return nil, errutil.NewAppError(errutil.ErrExternalServiceError, fmt.Errorf("%w: %w", types.ErrInvalidAIOutput, err))
+	}
+	return aiResp, nil
+}
+
+func (s *AnthropicServiceImpl) GetModelType() string {
+	return "anthropic"
+}
+
+// anthropicCall forces a call to the answer tool, whose input schema is the
+// response schema, so the tool input is the structured answer.
+func anthropicCall(ctx context.Context, model *models.AnthropicModel, prompt *types.Prompt, req *models.CurlRequest) (*anthropic.Response, error) {
+	responseSchema, err := req.ResponseSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	// The Messages API starts with a user turn, so the content goes in as context blocks before the question.
+	content := make([]anthropic.ContentBlock, 0, len(prompt.Context)+1)
+	for _, text := range prompt.Context {
+		content = append(content, anthropic.ContentBlock{Type: anthropic.ContentText, Text: text})
+	}
+	content = append(content, anthropic.ContentBlock{Type: anthropic.ContentText, Text: prompt.Question})
+
+	body, err := json.Marshal(anthropic.Request{
+		Model:     model.ModelName,
+		MaxTokens: anthropicMaxTokens,
+		System:    prompt.System,
+		Messages:  []anthropic.Message{{Role: anthropic.RoleUser, Content: content}},
+		Tools: []anthropic.Tool{{
+			Name:        anthropicAnswerTool,
+			Description: "Record the answer to the question about the given content",
+			InputSchema: responseSchema.JSONSchema(),
+		}},
+		ToolChoice: &anthropic.ToolChoice{Type: "tool", Name: anthropicAnswerTool},
+	})
+	if err != nil {
+		return nil, errutil.NewAppError(errutil.ErrAIMarshalRequestFailed, err)
+	}
+
+	baseURL := anthropicBaseURL
+	if model.GetBaseURL() != "" {
+		baseURL = strings.TrimRight(model.GetBaseURL(), "/")
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return nil, errutil.NewAppError(errutil.ErrAICreateRequestFailed, err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-api-key", model.GetAPIKey())
+	httpReq.Header.Set("anthropic-version", anthropicVersion)
+
+	httpClient := &http.Client{Timeout: 2 * time.Minute}
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return nil, errutil.NewAppError(errutil.ErrExternalServiceError, err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errutil.NewAppError(errutil.ErrExternalServiceError, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr anthropic.ErrorResponse
+		statusErr := &types.AIStatusError{Provider: "anthropic", StatusCode: resp.StatusCode, Err: fmt.Errorf("%s", http.StatusText(resp.StatusCode))}
+		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Error.Message != "" {
+			statusErr.Err = fmt.Errorf("%s: %s", apiErr.Error.Type, apiErr.Error.Message)
+		}
+		return nil, errutil.NewAppError(errutil.ErrExternalServiceError, statusErr)
+	}
+
+	var anthropicResp anthropic.Response
+	if err := json.Unmarshal(respBody, &anthropicResp); err != nil {
+		return nil, errutil.NewAppError(errutil.ErrExternalServiceError, err)
+	}
+	return &anthropicResp, nil
+}
+
+func (s *AnthropicServiceImpl) CreateAIModel(c context.Context, model any) error {
+	anthropicModel := (model).(*models.AnthropicModel)
+	return s.CreateModel(c, anthropicModel)
+}
+
+func (s *AnthropicServiceImpl) UpdateAIModel(c context.Context, model any) (any, error) {
+	anthropicModel := (model).(*models.AnthropicModel)
+	return s.UpdateModel(c, anthropicModel.ID, anthropicModel)
+}
+
+func (s *AnthropicServiceImpl) GetAIModelById(ctx context.Context, id uint) (any, error) {
+	return s.GetModelById(ctx, id, nil)
+}
+
+func (s *AnthropicServiceImpl) GetAllAIModels(ctx context.Context) ([]any, error) {
+	allModels, err := s.GetAllModels(ctx, 100, 0)
+	if err != nil {
+		return nil, err
+	}
+	i := make([]any, len(allModels))
+	for idx, model := range allModels {
+		i[idx] = model
+	}
+	return i, err
+}
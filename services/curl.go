@@ -1,187 +1,314 @@
 package services
 
 import (
+	"NotificationManagement/config"
 	"NotificationManagement/domain"
 	"NotificationManagement/logger"
 	"NotificationManagement/models"
 	"NotificationManagement/repositories"
 	"NotificationManagement/services/helper"
 	"NotificationManagement/types"
+	"NotificationManagement/utils"
+	"NotificationManagement/utils/curlparser"
 	"NotificationManagement/utils/errutil"
+	"NotificationManagement/utils/extractor"
+	"NotificationManagement/utils/feed"
+	"NotificationManagement/utils/htmltext"
+	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"os/exec"
-	"regexp"
+	"net/http/cookiejar"
+	"net/url"
 	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
 )
 
 type CurlServiceImpl struct {
 	domain.CommonService[models.CurlRequest]
 	CurlRepo            domain.CurlRequestRepository
 	AdditionalFieldRepo domain.AdditionalFieldsRepository
+	SecretService       domain.SecretService
+	SnapshotRepo        domain.ResponseSnapshotRepository
+	HostRuleService     domain.HostRuleService
+	TokenCache          domain.TokenCache
+	HostLimiter         domain.HostLimiter
+	robots              *robotsCache
 }
 
 func (s *CurlServiceImpl) GetModelById(c context.Context, id uint, preloads *[]string) (*models.CurlRequest, error) {
 	if preloads == nil {
-		preloads = &[]string{"AdditionalFields"}
+		preloads = &[]string{"AdditionalFields", "Steps"}
 	}
 	return s.CurlRepo.GetByID(s.GetInstance().ProcessContext(c), id, preloads)
 }
 
-func NewCurlService(repo domain.CurlRequestRepository, fieldsRepository domain.AdditionalFieldsRepository) domain.CurlService {
+// GetUserRequest returns the request with the given id if it belongs to userID.
+func (s *CurlServiceImpl) GetUserRequest(c context.Context, userID uint, id uint) (*models.CurlRequest, error) {
+	req, err := s.GetModelById(c, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserID != userID {
+		return nil, errutil.NewAppError(errutil.ErrRecordNotFound, fmt.Errorf("curl request %d not found", id))
+	}
+	return req, nil
+}
+
+func NewCurlService(repo domain.CurlRequestRepository, fieldsRepository domain.AdditionalFieldsRepository, snapshotRepo domain.ResponseSnapshotRepository, secretService domain.SecretService, hostRuleService domain.HostRuleService, tokenCache domain.TokenCache, hostLimiter domain.HostLimiter) domain.CurlService {
 	service := &CurlServiceImpl{
 		CurlRepo:            repo,
 		AdditionalFieldRepo: fieldsRepository,
+		SecretService:       secretService,
+		SnapshotRepo:        snapshotRepo,
+		HostRuleService:     hostRuleService,
+		TokenCache:          tokenCache,
+		HostLimiter:         hostLimiter,
+		robots:              newRobotsCache(),
 	}
 	service.CommonService = NewCommonService(repo, service)
 	return service
 }
 
-func parseBasicCurl(raw string) (method, url string, headers map[string]string, body string, err error) {
-	headers = make(map[string]string)
-	method = "GET"
-	body = ""
-
-	// Trim whitespace and collapse multiple spaces
-	raw = strings.TrimSpace(raw)
-	raw = regexp.MustCompile(`\s+`).ReplaceAllString(raw, " ")
-
-	// Match single, double, or escaped single quotes for the URL
-	re := regexp.MustCompile(`curl\s+(?:'([^']+)'|"([^"]+)"|\\'([^\\']+)\\')`)
-	matches := re.FindStringSubmatch(raw)
-	if len(matches) < 2 {
-		err = errutil.NewAppError(errutil.ErrCurlParseError, errors.New("could not parse URL from curl command"))
-		return
-	}
-	// Find the first non-empty group
-	for i := 1; i < len(matches); i++ {
-		if matches[i] != "" {
-			url = matches[i]
-			break
+// resolveRequest builds the structured HTTP request for a CurlRequest, either
+// by parsing its raw curl command or from the stored fields. Stored headers are
+// applied on top and placeholders are resolved with expand.
+func resolveRequest(req *models.CurlRequest, expand curlparser.ExpandFunc) (*curlparser.Request, error) {
+	var parsed *curlparser.Request
+	if req.RawCurl == "" {
+		method := req.Method
+		if method == "" {
+			method = http.MethodGet
 		}
-	}
-
-	// Find -X or --request for method
-	if strings.Contains(raw, " -X ") {
-		parts := strings.Split(raw, " -X ")
-		if len(parts) > 1 {
-			methodPart := strings.Fields(parts[1])
-			if len(methodPart) > 0 {
-				method = strings.ToUpper(methodPart[0])
+		target, err := expand(req.URL)
+		if err != nil {
+			return nil, err
+		}
+		body, err := expand(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		parsed = &curlparser.Request{
+			Method:  strings.ToUpper(method),
+			URL:     target,
+			Headers: map[string]string{},
+			Body:    body,
+		}
+	} else {
+		var err error
+		parsed, err = curlparser.ParseExpand(req.RawCurl, expand)
+		if err != nil {
+			var appErr *errutil.AppError
+			if errors.As(err, &appErr) {
+				return nil, err
 			}
+			return nil, errutil.NewAppError(errutil.ErrCurlParseError, err)
 		}
 	}
 
-	// Find all -H 'Header: value'
-	reHeader := regexp.MustCompile(`-H\s+'([^:]+):\s?([^']+)'`)
-	headersFound := reHeader.FindAllStringSubmatch(raw, -1)
-	for _, h := range headersFound {
-		if len(h) == 3 {
-			headers[h[1]] = h[2]
+	headers, err := req.GetHeaders()
+	if err != nil {
+		return nil, errutil.NewAppError(errutil.ErrCurlParseError, err)
+	}
+	for name, value := range headers {
+		value, err = expand(value)
+		if err != nil {
+			return nil, err
 		}
+		setHeader(parsed.Headers, name, value)
 	}
+	return parsed, nil
+}
 
-	// Find --data or -d for body
-	if strings.Contains(raw, "--data '") || strings.Contains(raw, "-d '") {
-		reBody := regexp.MustCompile(`(--data|-d)\s+'([^']+)'`)
-		bodyMatch := reBody.FindStringSubmatch(raw)
-		if len(bodyMatch) == 3 {
-			body = bodyMatch[2]
-			method = "POST"
-		}
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
 	}
+	return u.Host
+}
 
-	return
+func newHTTPClient(parsed *curlparser.Request, policy *fetchPolicy, jar http.CookieJar) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Every connection, redirects included, is checked against the owner's
+	// destination policy once the address is resolved. An environment proxy
+	// would hide the real destination from that check, so none is used.
+	transport.Proxy = nil
+	transport.DialContext = policy.destinations.DialContext(policy.connectTimeout)
+	if policy.proxy != nil {
+		// Connections then only go to the source's own proxy, which is checked
+		// when dialled; the destination of each request is checked by name.
+		proxy, destinations := policy.proxy, policy.destinations
+		transport.Proxy = func(request *http.Request) (*url.URL, error) {
+			if err := destinations.CheckHost(request.Context(), request.URL.Hostname()); err != nil {
+				return nil, err
+			}
+			return proxy, nil
+		}
+	}
+	if policy.tlsConfig != nil {
+		transport.TLSClientConfig = policy.tlsConfig.Clone()
+	}
+	if parsed.Insecure {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+	return &http.Client{
+		Transport: transport,
+		Jar:       jar,
+		Timeout:   policy.timeout,
+	}
 }
 
-func executeCurlCommand(command string) (string, error) {
-	cmd := exec.Command("sh", "-c", command)
-	output, err := cmd.CombinedOutput()
+// decodeCharset converts the body to UTF-8. A charset declared by the server is
+// always honoured; HTML documents are additionally sniffed for BOMs and <meta> tags.
+func decodeCharset(body []byte, contentType string, sniff bool) []byte {
+	enc, name, certain := charset.DetermineEncoding(body, contentType)
+	if !certain && (!sniff || utf8.Valid(body)) {
+		return body
+	}
+	if enc == nil || name == "utf-8" {
+		return body
+	}
+	decoded, err := enc.NewDecoder().Bytes(body)
 	if err != nil {
-		return "", errutil.NewAppErrorWithMessage(errutil.ErrCurlCommandExecutionFailed, err, fmt.Sprintf("Output: %s", output))
+		logger.Warn("Failed to decode response charset", "charset", name, "error", err)
+		return body
 	}
-	return string(output), nil
+	return decoded
 }
 
 func (s *CurlServiceImpl) ProcessCurlRequest(c context.Context, req *models.CurlRequest) (*types.CurlResponse, error) {
+	if resp, ok := prefetched(c, req.ID); ok {
+		return resp, nil
+	}
+	return s.fetchSource(c, req, nil, nil)
+}
 
-	if req.ResponseType == types.ResponseTypeHTML {
-		resp, err := executeCurlCommand(req.RawCurl)
-		if err != nil {
-			return nil, err
-		}
-		return &types.CurlResponse{
-			Status:     200,
-			Headers:    nil,
-			Body:       resp,
-			ErrMessage: "",
-		}, nil
+// fetchTrace records the raw response of the source request for dry runs.
+type fetchTrace struct {
+	status   int
+	finalURL string
+	headers  map[string]string
+	body     []byte
+}
 
+// fetchSource performs the request. Conditional headers are only added when the
+// source doesn't set them itself, and make a 304 an acceptable answer.
+func (s *CurlServiceImpl) fetchSource(c context.Context, req *models.CurlRequest, conditional map[string]string, trace *fetchTrace) (*types.CurlResponse, error) {
+	destinations, err := s.HostRuleService.PolicyFor(c, req.UserID)
+	if err != nil {
+		return &types.CurlResponse{}, err
+	}
+	// One jar per fetch keeps cookies set by login steps and redirects for the rest of the chain.
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return &types.CurlResponse{}, errutil.NewAppError(errutil.ErrExternalServiceError, err)
+	}
+	vars := map[string]string{}
+	expand := s.SecretService.Expander(c, req.UserID, vars)
+	if err := runSteps(c, req, expand, vars, jar, destinations, s.HostLimiter); err != nil {
+		return &types.CurlResponse{}, err
 	}
 
-	var method, urlStr, body string
-	headers := map[string]string{}
-
-	if req.RawCurl != "" {
-		m, u, h, b, err := parseBasicCurl(req.RawCurl)
-		if err != nil {
-			return &types.CurlResponse{}, errutil.NewAppErrorWithMessage(
-				errutil.ErrInvalidRequestBody,
-				err,
-				"Failed to parse raw curl command",
-			)
+	parsed, err := resolveRequest(req, expand)
+	if err != nil {
+		return &types.CurlResponse{}, err
+	}
+	for name, value := range conditional {
+		if _, ok := headerValue(parsed.Headers, name); !ok && value != "" {
+			parsed.Headers[name] = value
 		}
-		method = m
-		urlStr = u
-		headers = h
-		body = b
-	} else {
-		method = req.Method
-		urlStr = req.URL
-		body = req.Body
-		// TODO
-		//headers = req.Headers
 	}
 
-	logger.Info("Executing HTTP request", "method", method, "url", urlStr, "headers", headers, "body", body)
+	// Headers, query and body may carry resolved secrets, so only the target host is logged.
+	logger.Info("Executing HTTP request", "method", parsed.Method, "host", hostOf(parsed.URL))
 
-	transport := &http.Transport{}
-	if strings.Contains(req.RawCurl, "--insecure") {
-		transport.TLSClientConfig = &tls.Config{
-			InsecureSkipVerify: true,
+	policy, err := resolveFetchPolicy(req, parsed)
+	if err != nil {
+		return &types.CurlResponse{}, err
+	}
+	policy.destinations = destinations
+	policy.limiter = s.HostLimiter
+	if err := s.checkRobots(c, parsed, policy); err != nil {
+		return &types.CurlResponse{}, err
+	}
+	allowed := policy.allowed
+	if req.GraphQL.IsSet() {
+		if err := applyGraphQL(parsed, &req.GraphQL, expand); err != nil {
+			return &types.CurlResponse{}, err
 		}
+		policy.allowed = append(append(utils.StatusRanges{}, allowed...), graphQLErrorStatuses...)
 	}
-	client := &http.Client{
-		Transport: transport,
+	if len(conditional) > 0 {
+		policy.allowed = append(policy.allowed, [2]int{http.StatusNotModified, http.StatusNotModified})
 	}
-	request, err := http.NewRequest(method, urlStr, io.NopCloser(strings.NewReader(body)))
-	if err != nil {
-		return &types.CurlResponse{}, errutil.NewAppError(errutil.ErrExternalServiceError, err)
+	if err := s.authorize(c, req, parsed, policy, expand, false); err != nil {
+		return &types.CurlResponse{}, err
 	}
-	for k, v := range headers {
-		request.Header.Set(k, v)
+	client := newHTTPClient(parsed, policy, jar)
+	resp, respBody, err := fetch(c, client, parsed, policy)
+	if err != nil && req.Auth.IsOAuth() && isUnauthorized(err) {
+		// The cached token may have been revoked before it expired.
+		if err = s.authorize(c, req, parsed, policy, expand, true); err == nil {
+			resp, respBody, err = fetch(c, client, parsed, policy)
+		}
 	}
-
-	resp, err := client.Do(request)
 	if err != nil {
-		return &types.CurlResponse{}, errutil.NewAppError(errutil.ErrExternalServiceError, err)
+		return &types.CurlResponse{}, err
 	}
-	defer resp.Body.Close()
-	respBody, _ := io.ReadAll(resp.Body)
+	respBody = decodeCharset(respBody, resp.Header.Get("Content-Type"), req.ResponseType == types.ResponseTypeHTML)
 
 	respHeaders := map[string]string{}
 	for k, v := range resp.Header {
 		respHeaders[k] = v[0]
 	}
+	if trace != nil {
+		trace.status = resp.StatusCode
+		trace.finalURL = resp.Request.URL.String()
+		trace.headers = respHeaders
+		trace.body = respBody
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		return &types.CurlResponse{
+			Status:  resp.StatusCode,
+			Headers: respHeaders,
+			UserID:  req.UserID,
+		}, nil
+	}
 
 	var respBodyVal interface{}
-	if req.ResponseType == types.ResponseTypeHTML {
+	if req.Pagination.Mode != "" {
+		respBodyVal, err = followPages(c, req, parsed, policy, client, resp, respBody)
+		if err != nil {
+			return &types.CurlResponse{}, err
+		}
+	} else if req.GraphQL.IsSet() {
+		respBodyVal, err = graphQLData(req, resp.StatusCode, respBody, allowed)
+		if err != nil {
+			return &types.CurlResponse{}, err
+		}
+	} else if req.ResponseType == types.ResponseTypeHTML {
 		respBodyVal = string(respBody)
+	} else if req.ResponseType == types.ResponseTypeCSV {
+		respBodyVal, err = parseCSV(respBody)
+		if err != nil {
+			return &types.CurlResponse{}, errutil.NewAppError(errutil.ErrResponseParseFailed, err)
+		}
+	} else if req.ResponseType == types.ResponseTypeFeed {
+		parsedFeed, err := feed.Parse(respBody)
+		if err != nil {
+			return &types.CurlResponse{}, errutil.NewAppError(errutil.ErrResponseParseFailed, err)
+		}
+		respBodyVal = parsedFeed.ToMap()
 	} else if json.Valid(respBody) {
 		var jsonBody map[string]interface{}
 		if err := json.Unmarshal(respBody, &jsonBody); err == nil {
@@ -193,14 +320,88 @@ func (s *CurlServiceImpl) ProcessCurlRequest(c context.Context, req *models.Curl
 		respBodyVal = string(respBody)
 	}
 
+	if req.Extractor != "" {
+		respBodyVal, err = extractor.Extract(req.ResponseType, req.Extractor, respBodyVal)
+		if err != nil {
+			return &types.CurlResponse{}, errutil.NewAppError(errutil.ErrExtractionFailed, err)
+		}
+	}
+	if req.ResponseType == types.ResponseTypeHTML {
+		respBodyVal, err = toReadableText(respBodyVal.(string), parsed.URL, req)
+		if err != nil {
+			return &types.CurlResponse{}, errutil.NewAppError(errutil.ErrCurlInvalidResponseBodyType, err)
+		}
+	}
+
+	// Hashing the processed content means markup churn and changes outside the
+	// extracted selection don't count as changes.
 	return &types.CurlResponse{
-		Status:  resp.StatusCode,
-		Headers: respHeaders,
-		Body:    respBodyVal,
-		UserID:  req.UserID,
+		Status:      resp.StatusCode,
+		Headers:     respHeaders,
+		Body:        respBodyVal,
+		ContentHash: contentHash([]byte(snapshotBody(respBodyVal)), req.ResponseType),
+		UserID:      req.UserID,
 	}, nil
 }
 
+// parseCSV turns a CSV document into one object per row keyed by the header
+// row, the same shape a JSON array of records would decode to.
+func parseCSV(data []byte) ([]interface{}, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]interface{}, 0, len(records))
+	if len(records) == 0 {
+		return rows, nil
+	}
+	header := records[0]
+	for _, record := range records[1:] {
+		row := make(map[string]interface{}, len(header))
+		for i, name := range header {
+			if i < len(record) {
+				row[name] = record[i]
+			} else {
+				row[name] = ""
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// toReadableText converts an HTML page to markdown and cuts it to the content
+// budget so every provider receives the same compact text.
+func toReadableText(document, baseURL string, req *models.CurlRequest) (string, error) {
+	text, err := htmltext.ToMarkdown(document, baseURL)
+	if err != nil {
+		return "", err
+	}
+	budget := valueOr(config.Fetch().ContentBudget, 0)
+	if req.ContentBudget != nil {
+		budget = int(*req.ContentBudget)
+	}
+	return htmltext.Truncate(text, budget), nil
+}
+
+// PreviewExtraction fetches the source and applies the given extractor, or the
+// stored one when it's empty, without persisting anything.
+func (s *CurlServiceImpl) PreviewExtraction(c context.Context, req *models.CurlRequest, expr string) (*types.CurlResponse, error) {
+	preview := *req
+	if expr != "" {
+		if err := extractor.Validate(req.ResponseType, expr); err != nil {
+			return nil, errutil.NewAppError(errutil.ErrInvalidRequestBody, err)
+		}
+		preview.Extractor = expr
+	}
+	return s.fetchSource(c, &preview, nil, nil)
+}
+
 func (s *CurlServiceImpl) UpdateModel(c context.Context, id uint, model *models.CurlRequest) (*models.CurlRequest, error) {
 	existing, err := s.CurlRepo.GetByID(c, id, nil)
 	if err != nil {
@@ -243,6 +444,20 @@ func (s *CurlServiceImpl) UpdateModel(c context.Context, id uint, model *models.
 		return nil, err
 	}
 
+	// Steps are an ordered chain, so an update replaces them instead of merging by ID.
+	if model.Steps != nil {
+		for i := range *model.Steps {
+			(*model.Steps)[i].ID = 0
+			(*model.Steps)[i].RequestID = id
+		}
+		if _, err := helper.SyncHasManyAssociation(s.CurlRepo.GetDB(c), &existing, "Steps", model.Steps); err != nil {
+			return nil, err
+		}
+	}
+
+	model.Auth.KeepSecretsFrom(&existing.Auth)
+	model.Transport.KeepSecretsFrom(&existing.Transport)
+
 	model, err = s.CommonService.UpdateModel(c, id, model)
 	if err != nil {
 		return nil, err
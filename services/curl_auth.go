@@ -0,0 +1,180 @@
+package services
+
+import (
+	"NotificationManagement/logger"
+	"NotificationManagement/models"
+	"NotificationManagement/types"
+	"NotificationManagement/utils"
+	"NotificationManagement/utils/curlparser"
+	"NotificationManagement/utils/errutil"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// tokenExpirySkew renews cached tokens a little before the endpoint says they expire.
+	tokenExpirySkew = 30 * time.Second
+	// defaultTokenTTL is used when the token endpoint doesn't send expires_in.
+	defaultTokenTTL = 5 * time.Minute
+)
+
+type tokenResponse struct {
+	AccessToken  string      `json:"access_token"`
+	TokenType    string      `json:"token_type"`
+	ExpiresIn    json.Number `json:"expires_in"`
+	RefreshToken string      `json:"refresh_token"`
+}
+
+// authorize sets the Authorization header of a source request from its auth
+// block. OAuth access tokens come from the cache unless renew is set.
+func (s *CurlServiceImpl) authorize(c context.Context, req *models.CurlRequest, parsed *curlparser.Request, policy *fetchPolicy, expand curlparser.ExpandFunc, renew bool) error {
+	switch {
+	case req.Auth.Type == models.AuthBasic:
+		username, err := expand(req.Auth.Username)
+		if err != nil {
+			return err
+		}
+		password, err := expand(string(req.Auth.Password))
+		if err != nil {
+			return err
+		}
+		setHeader(parsed.Headers, "Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+	case req.Auth.IsOAuth():
+		token, err := s.accessToken(c, req, policy, expand, renew)
+		if err != nil {
+			return err
+		}
+		setHeader(parsed.Headers, "Authorization", "Bearer "+token)
+	}
+	return nil
+}
+
+// accessToken returns a cached access token for the source or requests a new
+// one from its token endpoint. Rotated refresh tokens are stored right away.
+func (s *CurlServiceImpl) accessToken(c context.Context, req *models.CurlRequest, policy *fetchPolicy, expand curlparser.ExpandFunc, renew bool) (string, error) {
+	auth := &req.Auth
+	clientID, err := expand(auth.ClientID)
+	if err != nil {
+		return "", err
+	}
+	clientSecret, err := expand(string(auth.ClientSecret))
+	if err != nil {
+		return "", err
+	}
+	refreshToken, err := expand(string(auth.RefreshToken))
+	if err != nil {
+		return "", err
+	}
+
+	key := tokenCacheKey(req, clientID, clientSecret)
+	if !renew {
+		token, ok, err := s.TokenCache.Get(c, key)
+		if err != nil {
+			logger.Warn("Failed to read cached access token", "request_id", req.ID, "error", err)
+		} else if ok {
+			return token, nil
+		}
+	}
+
+	form := url.Values{"grant_type": {auth.Type}}
+	if auth.Type == models.AuthRefreshToken {
+		if refreshToken == "" {
+			return "", errutil.NewAppError(errutil.ErrSourceAuthFailed, errors.New("no refresh token configured"))
+		}
+		form.Set("refresh_token", refreshToken)
+	}
+	if auth.Scopes != "" {
+		form.Set("scope", auth.Scopes)
+	}
+	headers := map[string]string{
+		"Content-Type": "application/x-www-form-urlencoded",
+		"Accept":       "application/json",
+	}
+	if clientSecret != "" {
+		credentials := url.QueryEscape(clientID) + ":" + url.QueryEscape(clientSecret)
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+	} else if clientID != "" {
+		form.Set("client_id", clientID)
+	}
+	tokenRequest := &curlparser.Request{
+		Method:  http.MethodPost,
+		URL:     auth.TokenURL,
+		Headers: headers,
+		Body:    form.Encode(),
+	}
+	tokenPolicy := *policy
+	tokenPolicy.allowed = utils.StatusRanges{{200, 299}}
+
+	logger.Info("Requesting access token", "request_id", req.ID, "grant", auth.Type, "host", hostOf(auth.TokenURL))
+	_, body, err := fetch(c, newHTTPClient(tokenRequest, &tokenPolicy, nil), tokenRequest, &tokenPolicy)
+	if err != nil {
+		var fetchErr *types.FetchError
+		if errors.As(err, &fetchErr) && fetchErr.Kind == types.FetchErrorStatusNotAllowed {
+			fetchErr.Kind = types.FetchErrorAuth
+		}
+		return "", err
+	}
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", errutil.NewAppError(errutil.ErrSourceAuthFailed, err)
+	}
+	if token.AccessToken == "" {
+		return "", errutil.NewAppError(errutil.ErrSourceAuthFailed, errors.New("token endpoint returned no access token"))
+	}
+
+	if auth.Type == models.AuthRefreshToken && token.RefreshToken != "" && token.RefreshToken != refreshToken {
+		auth.RefreshToken = models.EncryptedString(token.RefreshToken)
+		if req.ID != 0 {
+			if err := s.CurlRepo.UpdateRefreshToken(c, req.ID, token.RefreshToken); err != nil {
+				logger.Warn("Failed to store rotated refresh token", "request_id", req.ID, "error", err)
+			}
+		}
+	}
+
+	ttl := defaultTokenTTL
+	if seconds, err := strconv.ParseInt(token.ExpiresIn.String(), 10, 64); err == nil && seconds > 0 {
+		ttl = time.Duration(seconds)*time.Second - tokenExpirySkew
+	}
+	if ttl > 0 {
+		if err := s.TokenCache.Set(c, key, token.AccessToken, ttl); err != nil {
+			logger.Warn("Failed to cache access token", "request_id", req.ID, "error", err)
+		}
+	}
+	return token.AccessToken, nil
+}
+
+// tokenCacheKey identifies a grant without exposing its credentials. Sources of
+// one user with the same client credentials share a token; refresh token
+// grants belong to their source because the refresh token may rotate.
+func tokenCacheKey(req *models.CurlRequest, clientID, clientSecret string) string {
+	parts := []string{strconv.FormatUint(uint64(req.UserID), 10), req.Auth.Type, req.Auth.TokenURL, clientID, clientSecret, req.Auth.Scopes}
+	if req.Auth.Type == models.AuthRefreshToken {
+		parts = append(parts, strconv.FormatUint(uint64(req.ID), 10))
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+func isUnauthorized(err error) bool {
+	var fetchErr *types.FetchError
+	return errors.As(err, &fetchErr) && fetchErr.StatusCode == http.StatusUnauthorized
+}
+
+// setHeader replaces every case variant of name with a single value.
+func setHeader(headers map[string]string, name, value string) {
+	for existing := range headers {
+		if strings.EqualFold(existing, name) {
+			delete(headers, existing)
+		}
+	}
+	headers[name] = value
+}
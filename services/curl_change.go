@@ -0,0 +1,178 @@
+package services
+
+import (
+	"NotificationManagement/config"
+	"NotificationManagement/logger"
+	"NotificationManagement/models"
+	"NotificationManagement/types"
+	"NotificationManagement/utils/diffutil"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+type prefetchedKey struct{}
+
+type prefetchedResponse struct {
+	requestId uint
+	resp      *types.CurlResponse
+}
+
+// WithPrefetched lets a caller that already fetched a source hand the response
+// to the AI services, so a reminder run hits the upstream only once.
+func (s *CurlServiceImpl) WithPrefetched(c context.Context, requestId uint, resp *types.CurlResponse) context.Context {
+	return context.WithValue(c, prefetchedKey{}, &prefetchedResponse{requestId: requestId, resp: resp})
+}
+
+func prefetched(c context.Context, requestId uint) (*types.CurlResponse, bool) {
+	p, ok := c.Value(prefetchedKey{}).(*prefetchedResponse)
+	if !ok || p.requestId != requestId {
+		return nil, false
+	}
+	return p.resp, true
+}
+
+// CheckForChanges fetches the source with the validators of state and reports
+// whether its normalized content differs from what state saw. Nothing about the
+// state is stored here; the caller keeps the returned State once it has acted
+// on the response, so a failed run sees the same change again.
+func (s *CurlServiceImpl) CheckForChanges(c context.Context, req *models.CurlRequest, state types.FetchState) (*types.SourceChange, error) {
+	resp, err := s.fetchSource(c, req, map[string]string{
+		"If-None-Match":     state.ETag,
+		"If-Modified-Since": state.LastModified,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status == http.StatusNotModified {
+		return &types.SourceChange{Response: resp, State: state}, nil
+	}
+
+	change := &types.SourceChange{
+		Response: resp,
+		Changed:  resp.ContentHash != state.ContentHash,
+		State:    types.FetchState{ContentHash: resp.ContentHash},
+	}
+	change.State.ETag, _ = headerValue(resp.Headers, "ETag")
+	change.State.LastModified, _ = headerValue(resp.Headers, "Last-Modified")
+	if change.Changed {
+		s.storeSnapshot(c, req, resp)
+	}
+	return change, nil
+}
+
+// storeSnapshot saves the new body, keeps only the newest snapshots and, when
+// the request asks for it, attaches a diff against the previous snapshot.
+// Snapshots belong to the request, so a body another reminder already stored
+// isn't stored again.
+func (s *CurlServiceImpl) storeSnapshot(c context.Context, req *models.CurlRequest, resp *types.CurlResponse) {
+	body := snapshotBody(resp.Body)
+	previous, err := s.SnapshotRepo.GetLatest(c, req.ID, 2)
+	if err != nil {
+		logger.Warn("Failed to load previous snapshot", "request_id", req.ID, "error", err)
+	}
+
+	if len(previous) > 0 && previous[0].ContentHash == resp.ContentHash {
+		previous = previous[1:]
+	} else {
+		snapshot := &models.ResponseSnapshot{
+			RequestID:   req.ID,
+			StatusCode:  resp.Status,
+			ContentHash: resp.ContentHash,
+			Body:        body,
+		}
+		if err := s.SnapshotRepo.Create(c, snapshot); err != nil {
+			logger.Warn("Failed to store response snapshot", "request_id", req.ID, "error", err)
+			return
+		}
+		limit := valueOr(config.Fetch().SnapshotLimit, 5)
+		if req.SnapshotLimit != nil {
+			limit = int(*req.SnapshotLimit)
+		}
+		if err := s.SnapshotRepo.Prune(c, req.ID, limit); err != nil {
+			logger.Warn("Failed to prune response snapshots", "request_id", req.ID, "error", err)
+		}
+	}
+
+	if req.IncludeDiff && len(previous) > 0 {
+		diff, err := diffBodies(previous[0].Body, body, req.ResponseType)
+		if err != nil {
+			logger.Warn("Failed to diff response snapshots", "request_id", req.ID, "error", err)
+			return
+		}
+		resp.Diff = diff
+	}
+}
+
+func snapshotBody(body interface{}) string {
+	if text, ok := body.(string); ok {
+		return text
+	}
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
+
+// maxDiffLength keeps a diff of a rewritten page from crowding out the prompt.
+const maxDiffLength = 20000
+
+func diffBodies(previous, current, responseType string) (string, error) {
+	var diff string
+	var oldVal, newVal interface{}
+	if types.IsStructuredResponseType(responseType) &&
+		json.Unmarshal([]byte(previous), &oldVal) == nil &&
+		json.Unmarshal([]byte(current), &newVal) == nil {
+		diff = diffutil.FormatJSON(diffutil.JSON(oldVal, newVal))
+	} else {
+		var err error
+		diff, err = diffutil.Lines(previous, current, 3)
+		if err != nil {
+			return "", err
+		}
+	}
+	if len(diff) > maxDiffLength {
+		// End on a whole line where that doesn't lose much, and never inside a rune.
+		cut := maxDiffLength
+		if line := strings.LastIndexByte(diff[:cut], '\n'); line > maxDiffLength/2 {
+			cut = line
+		} else {
+			for cut > 0 && !utf8.RuneStart(diff[cut]) {
+				cut--
+			}
+		}
+		diff = diff[:cut] + "\n... (diff truncated)"
+	}
+	return diff, nil
+}
+
+// contentHash hashes the body after removing differences that don't change its
+// meaning: JSON is re-encoded with sorted keys, everything else has its
+// whitespace collapsed.
+func contentHash(body []byte, responseType string) string {
+	normalized := body
+	var v interface{}
+	if types.IsStructuredResponseType(responseType) && json.Unmarshal(body, &v) == nil {
+		if encoded, err := json.Marshal(v); err == nil {
+			normalized = encoded
+		}
+	} else {
+		normalized = []byte(strings.Join(strings.Fields(string(body)), " "))
+	}
+	sum := sha256.Sum256(normalized)
+	return hex.EncodeToString(sum[:])
+}
+
+func headerValue(headers map[string]string, name string) (string, bool) {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return "", false
+}
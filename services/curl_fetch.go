@@ -0,0 +1,200 @@
+package services
+
+import (
+	"NotificationManagement/config"
+	"NotificationManagement/domain"
+	"NotificationManagement/models"
+	"NotificationManagement/types"
+	"NotificationManagement/utils"
+	"NotificationManagement/utils/curlparser"
+	"NotificationManagement/utils/errutil"
+	"NotificationManagement/utils/netguard"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// fetchPolicy bounds a single source fetch. Values come from the CurlRequest,
+// then from the curl command's own flags, then from the global fetch config.
+type fetchPolicy struct {
+	connectTimeout time.Duration
+	timeout        time.Duration
+	maxRetries     int
+	backoff        time.Duration
+	maxSize        int64
+	allowed        utils.StatusRanges
+	destinations   *netguard.Policy
+	limiter        domain.HostLimiter
+	proxy          *url.URL
+	tlsConfig      *tls.Config
+}
+
+func resolveFetchPolicy(req *models.CurlRequest, parsed *curlparser.Request) (*fetchPolicy, error) {
+	defaults := config.Fetch()
+	policy := &fetchPolicy{
+		connectTimeout: secondsOr(req.ConnectTimeout, parsed.ConnectTimeout, defaults.ConnectTimeout),
+		timeout:        secondsOr(req.Timeout, parsed.MaxTime, defaults.Timeout),
+		maxRetries:     valueOr(defaults.MaxRetries, 0),
+		backoff:        time.Duration(valueOr(defaults.RetryBackoff, 0)) * time.Millisecond,
+		maxSize:        int64(valueOr(defaults.MaxResponseSize, 0)),
+	}
+	if req.MaxRetries != nil {
+		policy.maxRetries = int(*req.MaxRetries)
+	}
+	if req.MaxResponseSize != nil {
+		policy.maxSize = *req.MaxResponseSize
+	}
+
+	spec := req.AllowedStatuses
+	if spec == "" {
+		spec = defaults.AllowedStatuses
+	}
+	allowed, err := utils.ParseStatusRanges(spec)
+	if err != nil {
+		return nil, errutil.NewAppError(errutil.ErrInvalidRequestBody, err)
+	}
+	policy.allowed = allowed
+
+	policy.proxy, policy.tlsConfig, err = resolveTransport(&req.Transport)
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func secondsOr(stored *uint, flag time.Duration, fallback *int) time.Duration {
+	if stored != nil {
+		return time.Duration(*stored) * time.Second
+	}
+	if flag > 0 {
+		return flag
+	}
+	return time.Duration(valueOr(fallback, 0)) * time.Second
+}
+
+func valueOr(v *int, def int) int {
+	if v == nil {
+		return def
+	}
+	return *v
+}
+
+// fetch sends the request, retrying network failures and 5xx responses with
+// exponential backoff, and reads at most policy.maxSize bytes of the body.
+func fetch(c context.Context, client *http.Client, parsed *curlparser.Request, policy *fetchPolicy) (*http.Response, []byte, error) {
+	for attempt := 1; ; attempt++ {
+		request, err := newRequest(c, parsed)
+		if err != nil {
+			return nil, nil, errutil.NewAppError(errutil.ErrExternalServiceError, err)
+		}
+
+		if err := policy.throttle(c, request.URL.Hostname()); err != nil {
+			var fetchErr *types.FetchError
+			if errors.As(err, &fetchErr) {
+				fetchErr.Attempts = attempt
+				return nil, nil, err
+			}
+			return nil, nil, fetchFailed(types.FetchErrorTimeout, 0, attempt, err)
+		}
+		resp, err := client.Do(request)
+		lastAttempt := attempt > policy.maxRetries
+		if err != nil {
+			kind := classifyNetworkError(err)
+			if lastAttempt || c.Err() != nil || kind == types.FetchErrorBlocked {
+				return nil, nil, fetchFailed(kind, 0, attempt, err)
+			}
+		} else if resp.StatusCode < http.StatusInternalServerError || lastAttempt {
+			body, err := readLimited(resp, policy.maxSize)
+			resp.Body.Close()
+			if errors.Is(err, errResponseTooLarge) {
+				return nil, nil, fetchFailed(types.FetchErrorTooLarge, resp.StatusCode, attempt, err)
+			}
+			if err != nil {
+				return nil, nil, fetchFailed(classifyNetworkError(err), resp.StatusCode, attempt, err)
+			}
+			if !policy.allowed.Allows(resp.StatusCode) {
+				return nil, nil, fetchFailed(types.FetchErrorStatusNotAllowed, resp.StatusCode, attempt, fmt.Errorf("unexpected status %s", resp.Status))
+			}
+			return resp, body, nil
+		} else {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		wait := policy.backoff << (attempt - 1)
+		select {
+		case <-c.Done():
+			return nil, nil, fetchFailed(types.FetchErrorTimeout, 0, attempt, c.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+func newRequest(c context.Context, parsed *curlparser.Request) (*http.Request, error) {
+	request, err := http.NewRequestWithContext(c, parsed.Method, parsed.URL, strings.NewReader(parsed.Body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range parsed.Headers {
+		// Leave content negotiation to the transport so compressed bodies are decoded transparently.
+		if strings.EqualFold(k, "Accept-Encoding") {
+			continue
+		}
+		request.Header.Set(k, v)
+	}
+	if request.Header.Get("User-Agent") == "" && config.Fetch().UserAgent != "" {
+		request.Header.Set("User-Agent", config.Fetch().UserAgent)
+	}
+	return request, nil
+}
+
+var errResponseTooLarge = errors.New("response body exceeds the size limit")
+
+func readLimited(resp *http.Response, maxSize int64) ([]byte, error) {
+	if maxSize <= 0 {
+		return io.ReadAll(resp.Body)
+	}
+	if resp.ContentLength > maxSize {
+		return nil, errResponseTooLarge
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > maxSize {
+		return nil, errResponseTooLarge
+	}
+	return body, nil
+}
+
+func classifyNetworkError(err error) types.FetchErrorKind {
+	var blocked *netguard.BlockedError
+	if errors.As(err, &blocked) {
+		return types.FetchErrorBlocked
+	}
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return types.FetchErrorTimeout
+	}
+	return types.FetchErrorNetwork
+}
+
+func fetchFailed(kind types.FetchErrorKind, status, attempts int, err error) error {
+	code := errutil.ErrSourceFetchFailed
+	if kind == types.FetchErrorBlocked || kind == types.FetchErrorRobots {
+		code = errutil.ErrDestinationBlocked
+	}
+	return errutil.NewAppError(code, &types.FetchError{
+		Kind:       kind,
+		StatusCode: status,
+		Attempts:   attempts,
+		Err:        err,
+	})
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"NotificationManagement/logger"
+	"NotificationManagement/models"
+	"NotificationManagement/types"
+	"NotificationManagement/utils"
+	"NotificationManagement/utils/curlparser"
+	"NotificationManagement/utils/errutil"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// graphQLErrorStatuses are the statuses GraphQL servers use to report invalid
+// queries along with an errors list, which is more useful than the status alone.
+var graphQLErrorStatuses = utils.StatusRanges{{http.StatusBadRequest, http.StatusBadRequest}, {http.StatusUnprocessableEntity, http.StatusUnprocessableEntity}}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string        `json:"message"`
+		Path    []interface{} `json:"path"`
+	} `json:"errors"`
+}
+
+// applyGraphQL turns the request into a JSON POST of the source's query.
+// Placeholders in string variables are resolved with expand.
+func applyGraphQL(parsed *curlparser.Request, graphQL *models.RequestGraphQL, expand curlparser.ExpandFunc) error {
+	payload := map[string]interface{}{"query": graphQL.Query}
+	if graphQL.Variables != "" {
+		var variables interface{}
+		if err := json.Unmarshal([]byte(graphQL.Variables), &variables); err != nil {
+			return errutil.NewAppError(errutil.ErrCurlParseError, err)
+		}
+		variables, err := expandValues(variables, expand)
+		if err != nil {
+			return err
+		}
+		payload["variables"] = variables
+	}
+	if graphQL.OperationName != "" {
+		payload["operationName"] = graphQL.OperationName
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errutil.NewAppError(errutil.ErrCurlParseError, err)
+	}
+
+	parsed.Method = http.MethodPost
+	parsed.Body = string(body)
+	setHeader(parsed.Headers, "Content-Type", "application/json")
+	if _, ok := headerValue(parsed.Headers, "Accept"); !ok {
+		parsed.Headers["Accept"] = "application/json"
+	}
+	return nil
+}
+
+func expandValues(value interface{}, expand curlparser.ExpandFunc) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return expand(v)
+	case map[string]interface{}:
+		for key, item := range v {
+			expanded, err := expandValues(item, expand)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = expanded
+		}
+	case []interface{}:
+		for i, item := range v {
+			expanded, err := expandValues(item, expand)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = expanded
+		}
+	}
+	return value, nil
+}
+
+// graphQLData returns the data of a GraphQL response. Errors fail the fetch
+// unless the source accepts partial data and some data came back, in which
+// case they are only logged.
+func graphQLData(req *models.CurlRequest, status int, body []byte, allowed utils.StatusRanges) (interface{}, error) {
+	var response graphQLResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		if !allowed.Allows(status) {
+			return nil, fetchFailed(types.FetchErrorStatusNotAllowed, status, 1, fmt.Errorf("unexpected status %d", status))
+		}
+		return nil, errutil.NewAppError(errutil.ErrResponseParseFailed, err)
+	}
+	hasData := len(response.Data) > 0 && string(response.Data) != "null"
+
+	if len(response.Errors) > 0 {
+		messages := make([]string, 0, len(response.Errors))
+		for _, item := range response.Errors {
+			if len(item.Path) > 0 {
+				messages = append(messages, fmt.Sprintf("%s (at %v)", item.Message, item.Path))
+			} else {
+				messages = append(messages, item.Message)
+			}
+		}
+		err := fmt.Errorf("graphql: %s", strings.Join(messages, "; "))
+		if !hasData || !req.GraphQL.AllowPartial || !allowed.Allows(status) {
+			return nil, fetchFailed(types.FetchErrorGraphQL, status, 1, err)
+		}
+		logger.Warn("GraphQL source returned partial data", "request_id", req.ID, "error", err)
+	} else if !allowed.Allows(status) {
+		return nil, fetchFailed(types.FetchErrorStatusNotAllowed, status, 1, fmt.Errorf("unexpected status %d", status))
+	}
+
+	if !hasData {
+		return nil, errutil.NewAppError(errutil.ErrResponseParseFailed, errors.New("graphql response has no data"))
+	}
+	var data interface{}
+	if err := json.Unmarshal(response.Data, &data); err != nil {
+		return nil, errutil.NewAppError(errutil.ErrResponseParseFailed, err)
+	}
+	return data, nil
+}
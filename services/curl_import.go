@@ -0,0 +1,156 @@
+package services
+
+import (
+	"NotificationManagement/models"
+	"NotificationManagement/repositories"
+	"NotificationManagement/types"
+	"NotificationManagement/utils/curlparser"
+	"NotificationManagement/utils/errutil"
+	"NotificationManagement/utils/importer"
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ImportRequests creates a request for every importable entry of a HAR file
+// or Postman collection. Entries that don't pass validation are reported as
+// skipped instead of failing the whole import. The requests are saved in one
+// transaction, so an import that fails to save leaves nothing behind and can
+// be retried without creating duplicates.
+func (s *CurlServiceImpl) ImportRequests(c context.Context, userID uint, format string, req *types.CurlImportRequest) (*types.CurlImportResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errutil.NewAppError(errutil.ErrInvalidRequestBody, err)
+	}
+	parsed, err := importer.Parse(format, req.Content)
+	if err != nil {
+		return nil, errutil.NewAppError(errutil.ErrImportParseFailed, err)
+	}
+
+	result := &types.CurlImportResponse{Created: []*models.CurlRequest{}, Skipped: parsed.Skipped}
+	err = s.CurlRepo.GetDB(c).Transaction(func(tx *gorm.DB) error {
+		ctx := context.WithValue(c, repositories.TXContextKey, &repositories.TxContextKey{DB: tx})
+		for _, entry := range parsed.Entries {
+			model, err := importedRequest(entry, req.ResponseType).ToModel()
+			if err != nil {
+				result.Skipped = append(result.Skipped, importer.Skip{Name: entry.Name, Reason: importFailure(err)})
+				continue
+			}
+			model.UserID = userID
+			if err := s.CreateModel(ctx, model); err != nil {
+				return err
+			}
+			result.Created = append(result.Created, model)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func importedRequest(entry importer.Entry, responseType string) *types.CurlRequest {
+	if responseType == "" {
+		responseType = entry.ResponseType
+	}
+	if responseType == "" {
+		responseType = types.ResponseTypeJSON
+	}
+	req := &types.CurlRequest{
+		URL:          entry.URL,
+		Method:       entry.Method,
+		Headers:      entry.Headers,
+		Body:         entry.Body,
+		ResponseType: responseType,
+	}
+	if auth := entry.Auth; auth != nil {
+		req.Auth = &types.RequestAuthRequest{
+			Username:     auth.Username,
+			Password:     auth.Password,
+			TokenURL:     auth.TokenURL,
+			ClientID:     auth.ClientID,
+			ClientSecret: auth.ClientSecret,
+			Scopes:       auth.Scopes,
+		}
+		switch auth.Type {
+		case importer.AuthBasic:
+			req.Auth.Type = models.AuthBasic
+		case importer.AuthClientCredentials:
+			req.Auth.Type = models.AuthClientCredentials
+		}
+	}
+	if entry.GraphQLQuery != "" {
+		req.Method = ""
+		req.GraphQL = &types.RequestGraphQLRequest{
+			Query:     entry.GraphQLQuery,
+			Variables: entry.GraphQLVariables,
+		}
+	}
+	return req
+}
+
+// importFailure describes why an entry was rejected without the generic
+// "invalid request body" prefix.
+func importFailure(err error) string {
+	var appErr *errutil.AppError
+	if errors.As(err, &appErr) && appErr.Err != nil {
+		return appErr.Err.Error()
+	}
+	return err.Error()
+}
+
+// ExportCurl renders a stored request, and the steps that run before it, as
+// curl commands. Placeholders are left as written so no secret is revealed;
+// basic auth becomes --user without a password, which curl prompts for.
+func (s *CurlServiceImpl) ExportCurl(c context.Context, req *models.CurlRequest) (*types.CurlExportResponse, error) {
+	command, err := exportCommand(req)
+	if err != nil {
+		return nil, err
+	}
+	result := &types.CurlExportResponse{Curl: command}
+	if req.Steps != nil {
+		steps := append([]models.RequestStep(nil), *req.Steps...)
+		sort.SliceStable(steps, func(i, j int) bool {
+			return steps[i].Position < steps[j].Position
+		})
+		for i := range steps {
+			command, err := exportCommand(steps[i].ToCurlRequest(req))
+			if err != nil {
+				return nil, err
+			}
+			result.Steps = append(result.Steps, command)
+		}
+	}
+	return result, nil
+}
+
+func exportCommand(req *models.CurlRequest) (string, error) {
+	keep := func(text string) (string, error) { return text, nil }
+	parsed, err := resolveRequest(req, keep)
+	if err != nil {
+		return "", err
+	}
+	if req.GraphQL.IsSet() {
+		if err := applyGraphQL(parsed, &req.GraphQL, keep); err != nil {
+			return "", err
+		}
+	}
+	if req.ConnectTimeout != nil {
+		parsed.ConnectTimeout = time.Duration(*req.ConnectTimeout) * time.Second
+	}
+	if req.Timeout != nil {
+		parsed.MaxTime = time.Duration(*req.Timeout) * time.Second
+	}
+
+	command := curlparser.Render(parsed)
+	switch {
+	case req.Auth.Type == models.AuthBasic:
+		command += " \\\n  --user " + curlparser.Quote(req.Auth.Username)
+	case req.Auth.IsOAuth():
+		command += " \\\n  -H " + curlparser.Quote("Authorization: Bearer <access token>")
+	}
+	return command, nil
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"NotificationManagement/config"
+	"NotificationManagement/logger"
+	"NotificationManagement/models"
+	"NotificationManagement/utils/curlparser"
+	"NotificationManagement/utils/errutil"
+	"NotificationManagement/utils/extractor"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// followPages collects the items of every page of a paginated source, starting
+// with the page already fetched, until there is no next page, a page comes
+// back empty or the page limit is reached.
+func followPages(c context.Context, req *models.CurlRequest, parsed *curlparser.Request, policy *fetchPolicy, client *http.Client, resp *http.Response, body []byte) ([]interface{}, error) {
+	pagination := &req.Pagination
+	maxPages := valueOr(config.Fetch().MaxPages, 10)
+	if pagination.MaxPages != nil {
+		maxPages = int(*pagination.MaxPages)
+	}
+
+	items, err := pageItems(pagination.ItemsPath, body)
+	if err != nil {
+		return nil, err
+	}
+	all := items
+
+	// Conditional headers only apply to the first page; later pages are always fetched in full.
+	page := *parsed
+	page.Headers = map[string]string{}
+	for name, value := range parsed.Headers {
+		if !strings.EqualFold(name, "If-None-Match") && !strings.EqualFold(name, "If-Modified-Since") {
+			page.Headers[name] = value
+		}
+	}
+	position, err := startPosition(pagination, parsed.URL)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{parsed.URL: true}
+
+	for fetched := 1; fetched < maxPages && len(items) > 0; fetched++ {
+		next, err := nextPageURL(pagination, parsed.URL, resp, body, position, len(items))
+		if err != nil {
+			return nil, err
+		}
+		if next == "" || seen[next] {
+			break
+		}
+		seen[next] = true
+		// Only follow pages on the source's own host so its credentials aren't sent elsewhere.
+		if hostOf(next) != hostOf(parsed.URL) {
+			return nil, errutil.NewAppError(errutil.ErrResponseParseFailed, fmt.Errorf("next page %s is on another host", hostOf(next)))
+		}
+
+		page.URL = next
+		logger.Info("Fetching next page", "request_id", req.ID, "page", fetched+1, "host", hostOf(next))
+		resp, body, err = fetch(c, client, &page, policy)
+		if err != nil {
+			return nil, err
+		}
+		if items, err = pageItems(pagination.ItemsPath, body); err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if pagination.Mode == models.PaginationOffset {
+			position += len(items)
+		} else {
+			position++
+		}
+	}
+	return all, nil
+}
+
+// pageItems returns the items of one page: the array at itemsPath, or the
+// whole body when no path is set.
+func pageItems(itemsPath string, body []byte) ([]interface{}, error) {
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, errutil.NewAppError(errutil.ErrResponseParseFailed, err)
+	}
+	if itemsPath != "" {
+		var err error
+		decoded, err = extractor.Extract(extractor.TypeJSON, itemsPath, decoded)
+		if errors.Is(err, extractor.ErrNoMatch) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errutil.NewAppError(errutil.ErrExtractionFailed, err)
+		}
+	}
+	if items, ok := decoded.([]interface{}); ok {
+		return items, nil
+	}
+	if decoded == nil {
+		return nil, nil
+	}
+	return []interface{}{decoded}, nil
+}
+
+// startPosition is the page number or offset of the first page, taken from the
+// source URL when it already carries the parameter.
+func startPosition(pagination *models.RequestPagination, rawURL string) (int, error) {
+	if pagination.Mode != models.PaginationPage && pagination.Mode != models.PaginationOffset {
+		return 0, nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, errutil.NewAppError(errutil.ErrCurlParseError, err)
+	}
+	if value := u.Query().Get(pagination.Param); value != "" {
+		position, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, errutil.NewAppError(errutil.ErrCurlParseError, fmt.Errorf("%s=%q is not a number", pagination.Param, value))
+		}
+		return position, nil
+	}
+	if pagination.Mode == models.PaginationPage {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// nextPageURL works out the URL of the page after the one in resp/body. An
+// empty result means there are no more pages.
+func nextPageURL(pagination *models.RequestPagination, sourceURL string, resp *http.Response, body []byte, position, count int) (string, error) {
+	current := resp.Request.URL
+	switch pagination.Mode {
+	case models.PaginationLink:
+		next := linkNext(resp.Header.Values("Link"))
+		if next == "" {
+			return "", nil
+		}
+		return resolveReference(current, next)
+	case models.PaginationCursor:
+		cursor, err := extractor.Extract(extractor.TypeJSON, pagination.CursorPath, string(body))
+		if errors.Is(err, extractor.ErrNoMatch) {
+			return "", nil
+		}
+		if err != nil {
+			return "", errutil.NewAppError(errutil.ErrExtractionFailed, err)
+		}
+		var value string
+		switch v := cursor.(type) {
+		case string:
+			value = v
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			if !v {
+				return "", nil
+			}
+			value = strconv.FormatBool(v)
+		default:
+			return "", errutil.NewAppError(errutil.ErrExtractionFailed, fmt.Errorf("cursor at %s is not a string or number", pagination.CursorPath))
+		}
+		if value == "" {
+			return "", nil
+		}
+		// Some APIs return the whole next page URL instead of a bare cursor.
+		if strings.HasPrefix(value, "/") || strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+			return resolveReference(current, value)
+		}
+		return withQueryParam(sourceURL, pagination.Param, value)
+	case models.PaginationPage:
+		return withQueryParam(sourceURL, pagination.Param, strconv.Itoa(position+1))
+	case models.PaginationOffset:
+		return withQueryParam(sourceURL, pagination.Param, strconv.Itoa(position+count))
+	}
+	return "", nil
+}
+
+// linkNext returns the target of the rel="next" entry of RFC 8288 Link headers.
+func linkNext(headers []string) string {
+	for _, header := range headers {
+		for _, link := range strings.Split(header, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range parts[1:] {
+				name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(strings.TrimSpace(name), "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func resolveReference(base *url.URL, ref string) (string, error) {
+	u, err := base.Parse(ref)
+	if err != nil {
+		return "", errutil.NewAppError(errutil.ErrResponseParseFailed, err)
+	}
+	return u.String(), nil
+}
+
+func withQueryParam(rawURL, name, value string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errutil.NewAppError(errutil.ErrCurlParseError, err)
+	}
+	query := u.Query()
+	query.Set(name, value)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"NotificationManagement/config"
+	"NotificationManagement/logger"
+	"NotificationManagement/types"
+	"NotificationManagement/utils"
+	"NotificationManagement/utils/curlparser"
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/temoto/robotstxt"
+)
+
+// maxThrottleWait bounds the wait for a host slot when neither the request's
+// timeout nor its context say how long the fetch may take.
+const maxThrottleWait = time.Minute
+
+const (
+	robotsTTL      = time.Hour
+	robotsRetryTTL = 10 * time.Minute
+	robotsMaxSize  = 512 << 10
+)
+
+// throttle waits for the host's next free slot under the shared per-host rate
+// limit. Waits get up to 20% jitter so workers released together spread out.
+// A wait longer than the fetch timeout or the time left on c fails the fetch
+// instead of holding the worker. The limit fails open: if Redis can't be
+// reached the request goes out.
+func (p *fetchPolicy) throttle(c context.Context, host string) error {
+	if p.limiter == nil {
+		return nil
+	}
+	defaults := config.Fetch()
+	wait, err := p.limiter.Reserve(c, host, valueOr(defaults.HostRateLimit, 0), valueOr(defaults.HostBurst, 1))
+	if err != nil {
+		logger.Warn("Failed to reserve a request slot for host", "host", host, "error", err)
+		return nil
+	}
+	if wait <= 0 {
+		return nil
+	}
+	wait += time.Duration(rand.Int63n(int64(wait)/5 + 1))
+	if limit := p.maxWait(c); wait > limit {
+		return fetchFailed(types.FetchErrorRateLimited, 0, 0, fmt.Errorf("next slot for %s is %s away, more than the %s the fetch may wait", host, wait.Round(time.Second), limit.Round(time.Millisecond)))
+	}
+	logger.Info("Delaying request to respect the host rate limit", "host", host, "wait", wait.String())
+	select {
+	case <-c.Done():
+		return c.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+func (p *fetchPolicy) maxWait(c context.Context) time.Duration {
+	limit := maxThrottleWait
+	if p.timeout > 0 && p.timeout < limit {
+		limit = p.timeout
+	}
+	if deadline, ok := c.Deadline(); ok && time.Until(deadline) < limit {
+		limit = time.Until(deadline)
+	}
+	return limit
+}
+
+// userAgent is the User-Agent a request is sent with.
+func userAgent(parsed *curlparser.Request) string {
+	if agent, ok := headerValue(parsed.Headers, "User-Agent"); ok {
+		return agent
+	}
+	return config.Fetch().UserAgent
+}
+
+type robotsEntry struct {
+	data    *robotstxt.RobotsData
+	expires time.Time
+}
+
+// robotsCache keeps the parsed robots.txt of each origin for an hour.
+type robotsCache struct {
+	mu      sync.Mutex
+	entries map[string]robotsEntry
+}
+
+func newRobotsCache() *robotsCache {
+	return &robotsCache{entries: map[string]robotsEntry{}}
+}
+
+// checkRobots fails the fetch when robots.txt compliance is enabled and the
+// source's robots.txt disallows its URL for the User-Agent in use.
+func (s *CurlServiceImpl) checkRobots(c context.Context, parsed *curlparser.Request, policy *fetchPolicy) error {
+	if respect := config.Fetch().RespectRobotsTxt; respect == nil || !*respect {
+		return nil
+	}
+	target, err := url.Parse(parsed.URL)
+	if err != nil {
+		return nil
+	}
+	agent := userAgent(parsed)
+	data := s.robots.get(c, target, agent, policy)
+	if data.TestAgent(target.RequestURI(), agent) {
+		return nil
+	}
+	return fetchFailed(types.FetchErrorRobots, 0, 0, fmt.Errorf("robots.txt of %s disallows %s", target.Host, target.Path))
+}
+
+func (rc *robotsCache) get(c context.Context, target *url.URL, agent string, policy *fetchPolicy) *robotstxt.RobotsData {
+	origin := target.Scheme + "://" + target.Host
+	rc.mu.Lock()
+	entry, ok := rc.entries[origin]
+	rc.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.data
+	}
+
+	entry = robotsEntry{expires: time.Now().Add(robotsTTL)}
+	var err error
+	entry.data, err = fetchRobots(c, origin, agent, policy)
+	if err != nil {
+		// An unreachable robots.txt doesn't restrict anything, but is retried sooner.
+		logger.Warn("Failed to fetch robots.txt", "origin", origin, "error", err)
+		entry.data, _ = robotstxt.FromStatusAndBytes(http.StatusNotFound, nil)
+		entry.expires = time.Now().Add(robotsRetryTTL)
+	}
+	rc.mu.Lock()
+	rc.entries[origin] = entry
+	rc.mu.Unlock()
+	return entry.data
+}
+
+// fetchRobots reads robots.txt under the source's own destination and rate
+// limits. As usual, a missing file allows everything and a server error
+// disallows everything.
+func fetchRobots(c context.Context, origin, agent string, policy *fetchPolicy) (*robotstxt.RobotsData, error) {
+	request := &curlparser.Request{
+		Method:  http.MethodGet,
+		URL:     origin + "/robots.txt",
+		Headers: map[string]string{"User-Agent": agent},
+	}
+	robotsPolicy := *policy
+	robotsPolicy.maxRetries = 0
+	robotsPolicy.maxSize = robotsMaxSize
+	robotsPolicy.allowed = utils.StatusRanges{{100, 599}}
+	resp, body, err := fetch(c, newHTTPClient(request, &robotsPolicy, nil), request, &robotsPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return robotstxt.FromStatusAndBytes(resp.StatusCode, body)
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"NotificationManagement/domain"
+	"NotificationManagement/logger"
+	"NotificationManagement/models"
+	"NotificationManagement/utils/curlparser"
+	"NotificationManagement/utils/errutil"
+	"NotificationManagement/utils/extractor"
+	"NotificationManagement/utils/netguard"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+)
+
+// runSteps sends the steps of a source in order before its own request. Each
+// step sees the variables captured by the steps before it, and all of them
+// share the source's cookie jar, destination policy and host rate limit.
+func runSteps(c context.Context, req *models.CurlRequest, expand curlparser.ExpandFunc, vars map[string]string, jar http.CookieJar, destinations *netguard.Policy, limiter domain.HostLimiter) error {
+	if req.Steps == nil || len(*req.Steps) == 0 {
+		return nil
+	}
+	steps := append([]models.RequestStep(nil), *req.Steps...)
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].Position < steps[j].Position
+	})
+
+	for i := range steps {
+		step := &steps[i]
+		stepReq := step.ToCurlRequest(req)
+		parsed, err := resolveRequest(stepReq, expand)
+		if err != nil {
+			return err
+		}
+		logger.Info("Executing request step", "step", i+1, "method", parsed.Method, "host", hostOf(parsed.URL))
+
+		policy, err := resolveFetchPolicy(stepReq, parsed)
+		if err != nil {
+			return err
+		}
+		policy.destinations = destinations
+		policy.limiter = limiter
+		resp, body, err := fetch(c, newHTTPClient(parsed, policy, jar), parsed, policy)
+		if err != nil {
+			return err
+		}
+
+		captures, err := step.GetCaptures()
+		if err != nil {
+			return errutil.NewAppError(errutil.ErrCurlParseError, err)
+		}
+		body = decodeCharset(body, resp.Header.Get("Content-Type"), false)
+		for _, capture := range captures {
+			value, err := captureValue(capture, resp, body, jar)
+			if err != nil {
+				return errutil.NewAppError(errutil.ErrStepCaptureFailed, fmt.Errorf("step %d, %s: %w", i+1, capture.Name, err))
+			}
+			vars[capture.Name] = value
+		}
+	}
+	return nil
+}
+
+func captureValue(capture models.StepCapture, resp *http.Response, body []byte, jar http.CookieJar) (string, error) {
+	switch capture.From {
+	case models.CaptureFromJSON:
+		value, err := extractor.Extract(extractor.TypeJSON, capture.Expr, string(body))
+		if err != nil {
+			return "", err
+		}
+		if text, ok := value.(string); ok {
+			return text, nil
+		}
+		encoded, err := json.Marshal(value)
+		return string(encoded), err
+	case models.CaptureFromHeader:
+		if value := resp.Header.Get(capture.Expr); value != "" {
+			return value, nil
+		}
+		return "", fmt.Errorf("header %q not in response", capture.Expr)
+	case models.CaptureFromCookie:
+		for _, cookie := range resp.Cookies() {
+			if cookie.Name == capture.Expr {
+				return cookie.Value, nil
+			}
+		}
+		for _, cookie := range jar.Cookies(resp.Request.URL) {
+			if cookie.Name == capture.Expr {
+				return cookie.Value, nil
+			}
+		}
+		return "", fmt.Errorf("cookie %q not set", capture.Expr)
+	case models.CaptureFromRegex:
+		pattern, err := regexp.Compile(capture.Expr)
+		if err != nil {
+			return "", err
+		}
+		match := pattern.FindSubmatch(body)
+		if match == nil {
+			return "", fmt.Errorf("pattern %q did not match", capture.Expr)
+		}
+		if len(match) > 1 {
+			return string(match[1]), nil
+		}
+		return string(match[0]), nil
+	default:
+		return "", fmt.Errorf("unknown capture source %q", capture.From)
+	}
+}
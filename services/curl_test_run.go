@@ -0,0 +1,79 @@
+package services
+
+import (
+	"NotificationManagement/models"
+	"NotificationManagement/types"
+	"context"
+	"errors"
+	"time"
+	"unicode/utf8"
+)
+
+// bodyPreviewLimit caps the raw body returned by a dry run, in bytes.
+const bodyPreviewLimit = 4096
+
+// TestRequest runs a source definition once without persisting anything: the
+// request itself, snapshots, feed state and fetch state are left untouched.
+// Failures of the source are reported in the result next to what was sent.
+func (s *CurlServiceImpl) TestRequest(c context.Context, req *models.CurlRequest) (*types.CurlTestResponse, error) {
+	// Placeholders are left as written so the result doesn't echo secrets.
+	keep := func(text string) (string, error) { return text, nil }
+	display, err := resolveRequest(req, keep)
+	if err != nil {
+		return nil, err
+	}
+	if req.GraphQL.IsSet() {
+		if err := applyGraphQL(display, &req.GraphQL, keep); err != nil {
+			return nil, err
+		}
+	}
+	if req.Auth.Type != "" {
+		setHeader(display.Headers, "Authorization", "<"+req.Auth.Type+" auth>")
+	}
+
+	result := &types.CurlTestResponse{
+		Method:  display.Method,
+		URL:     display.URL,
+		Headers: display.Headers,
+	}
+	trace := &fetchTrace{}
+	started := time.Now()
+	resp, err := s.fetchSource(c, req, nil, trace)
+	result.DurationMs = time.Since(started).Milliseconds()
+
+	result.Status = trace.status
+	result.FinalURL = trace.finalURL
+	result.ResponseHeaders = trace.headers
+	result.BodyPreview, result.BodyTruncated = preview(trace.body, bodyPreviewLimit)
+	if err != nil {
+		var fetchErr *types.FetchError
+		if trace.body == nil && !errors.As(err, &fetchErr) {
+			return nil, err
+		}
+		if fetchErr != nil && result.Status == 0 {
+			result.Status = fetchErr.StatusCode
+		}
+		result.Error = err.Error()
+		return result, nil
+	}
+
+	content, err := resp.GetAssistantContent(req.ResponseType)
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	result.AssistantContent = *content
+	return result, nil
+}
+
+// preview cuts body to at most limit bytes without splitting a character.
+func preview(body []byte, limit int) (string, bool) {
+	if len(body) <= limit {
+		return string(body), false
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return string(body[:cut]), true
+}
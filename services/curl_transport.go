@@ -0,0 +1,46 @@
+package services
+
+import (
+	"NotificationManagement/models"
+	"NotificationManagement/utils/errutil"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/url"
+)
+
+// resolveTransport turns the stored proxy and TLS settings of a source into
+// the proxy URL and TLS configuration its connections use. Both are nil when
+// the source doesn't set them.
+func resolveTransport(settings *models.RequestTransport) (*url.URL, *tls.Config, error) {
+	var proxy *url.URL
+	if settings.ProxyURL != "" {
+		var err error
+		if proxy, err = url.Parse(settings.ProxyURL); err != nil {
+			return nil, nil, errutil.NewAppError(errutil.ErrTransportConfig, err)
+		}
+		if settings.ProxyUsername != "" {
+			proxy.User = url.UserPassword(settings.ProxyUsername, string(settings.ProxyPassword))
+		}
+	}
+
+	if settings.CABundle == "" && settings.ClientCert == "" {
+		return proxy, nil, nil
+	}
+	config := &tls.Config{}
+	if settings.CABundle != "" {
+		// Like curl --cacert, the bundle replaces the system roots.
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM([]byte(settings.CABundle)) {
+			return nil, nil, errutil.NewAppError(errutil.ErrTransportConfig, errors.New("the CA bundle contains no PEM certificates"))
+		}
+	}
+	if settings.ClientCert != "" {
+		certificate, err := tls.X509KeyPair([]byte(settings.ClientCert), []byte(settings.ClientKey))
+		if err != nil {
+			return nil, nil, errutil.NewAppError(errutil.ErrTransportConfig, err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return proxy, config, nil
+}
@@ -20,11 +20,13 @@ import (
 type DeepseekServiceImpl struct {
 	domain.CommonService[models.DeepseekModel]
 	CurlService domain.CurlService
+	Prompts     domain.PromptTemplateService
 }
 
-func NewDeepseekModelService(repo domain.DeepseekModelRepository, curl domain.CurlService) domain.DeepseekService {
+func NewDeepseekModelService(repo domain.DeepseekModelRepository, curl domain.CurlService, prompts domain.PromptTemplateService) domain.DeepseekService {
 	service := &DeepseekServiceImpl{
 		CurlService: curl,
+		Prompts:     prompts,
 	}
 	service.CommonService = NewCommonService(repo, service)
 	return service
@@ -52,7 +54,11 @@ func (s *DeepseekServiceImpl) MakeAIRequest(c context.Context, m *models.AIModel
 	if err != nil {
 		return nil, err
 	}
-	respBody, err := deepseekCall(model, curlResponse, curl)
+	prompt, err := s.Prompts.BuildPrompt(c, curl, curlResponse)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := deepseekCall(model, prompt, curl)
 	if err != nil {
 		return nil, errutil.NewAppError(errutil.ErrExternalServiceError, err)
 	}
@@ -115,46 +121,35 @@ func (s *DeepseekServiceImpl) PullModel(_ context.Context, model *models.Deepsee
 	return nil
 }
 
-func deepseekCall(model *models.DeepseekModel, response *types.CurlResponse, curl *models.CurlRequest) ([]byte, error) {
-	assistantContent, err := response.GetAssistantContent(curl.ResponseType)
+func deepseekCall(model *models.DeepseekModel, prompt *types.Prompt, curl *models.CurlRequest) ([]byte, error) {
+	responseSchema, err := curl.ResponseSchema()
 	if err != nil {
 		return nil, err
 	}
 
-	properties := curl.GetOllamaSchemaProperties()
-	properties["IsCorrect"] = ollama.FormatProperty{
-		Type:        "boolean",
-		Description: "This holds the true or false value for the Statement",
+	var messages []*ollama.Message
+	if prompt.System != "" {
+		messages = append(messages, &ollama.Message{
+			Role:    "system",
+			Content: prompt.System,
+		})
 	}
-	if curl.ResponseType == types.ResponseTypeHTML {
-		s := response.Body.(string)
-		assistantContent = &s
+	for _, text := range prompt.Context {
+		messages = append(messages, &ollama.Message{
+			Role:    "assistant",
+			Content: text,
+		})
 	}
+	messages = append(messages, &ollama.Message{
+		Role:    "user",
+		Content: prompt.Question,
+	})
 
 	ollamaReq := ollama.Request{
-		Model: model.ModelName,
-		Messages: []*ollama.Message{
-			{
-				Role:    "assistant",
-				Content: *assistantContent,
-			},
-			{
-				Role:    "user",
-				Content: curl.Body,
-			},
-		},
-		Stream: false,
-		Format: &ollama.Format{
-			Type:       "object",
-			Properties: properties,
-			Required: func() []string {
-				requiredKeys := make([]string, 0, len(properties))
-				for key := range properties {
-					requiredKeys = append(requiredKeys, key)
-				}
-				return requiredKeys
-			}(),
-		},
+		Model:    model.ModelName,
+		Messages: messages,
+		Stream:   false,
+		Format:   responseSchema.JSONSchema(),
 		Options: &ollama.Options{
 			Temperature: 0.5,
 		},
@@ -184,6 +179,9 @@ func deepseekCall(model *models.DeepseekModel, response *types.CurlResponse, cur
 	if err != nil {
 		return nil, err
 	}
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return nil, &types.AIStatusError{Provider: "deepseek", StatusCode: res.StatusCode, Err: fmt.Errorf("%s", strings.TrimSpace(string(body)))}
+	}
 	return body, err
 }
 
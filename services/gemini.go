@@ -9,7 +9,6 @@ import (
 	"NotificationManagement/utils/errutil"
 	"context"
 	"encoding/json"
-	"os"
 
 	"google.golang.org/genai"
 )
@@ -17,11 +16,13 @@ import (
 type GeminiServiceImpl struct {
 	domain.CommonService[models.GeminiModel]
 	CurlService domain.CurlService
+	Prompts     domain.PromptTemplateService
 }
 
-func NewGeminiService(repo domain.GeminiModelRepository, curlService domain.CurlService) domain.GeminiService {
+func NewGeminiService(repo domain.GeminiModelRepository, curlService domain.CurlService, prompts domain.PromptTemplateService) domain.GeminiService {
 	service := &GeminiServiceImpl{
 		CurlService: curlService,
+		Prompts:     prompts,
 	}
 	service.CommonService = NewCommonService(repo, service)
 	return service
@@ -49,7 +50,11 @@ func (s *GeminiServiceImpl) MakeAIRequest(c context.Context, m *models.AIModel,
 	if err != nil {
 		return nil, err
 	}
-	respBody, err := geminiCall(c, model, curlResponse, curl)
+	prompt, err := s.Prompts.BuildPrompt(c, curl, curlResponse)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := geminiCall(c, model, prompt, curl)
 	if err != nil {
 		return nil, errutil.NewAppError(errutil.ErrExternalServiceError, err)
 	}
@@ -73,11 +78,7 @@ func (s *GeminiServiceImpl) GetModelType() string {
 	return "gemini"
 }
 
-func geminiCall(ctx context.Context, model *models.GeminiModel, response *types.CurlResponse, req *models.CurlRequest) (*genai.GenerateContentResponse, error) {
-	assistantContent, err := response.GetAssistantContent(req.ResponseType)
-	if err != nil {
-		return nil, err
-	}
+func geminiCall(ctx context.Context, model *models.GeminiModel, prompt *types.Prompt, req *models.CurlRequest) (*genai.GenerateContentResponse, error) {
 	client, err := genai.NewClient(ctx, &genai.ClientConfig{
 		APIKey: model.GetAPIKey(),
 		HTTPOptions: genai.HTTPOptions{
@@ -87,41 +88,28 @@ func geminiCall(ctx context.Context, model *models.GeminiModel, response *types.
 	if err != nil {
 		return nil, err
 	}
-	var parts []*genai.Part
 
-	if req.ResponseType == types.ResponseTypeHTML {
-		fileContent, err := os.ReadFile(*assistantContent)
-		if err != nil {
-			return nil, errutil.NewAppError(errutil.ErrExternalServiceError, err)
+	var gr []*genai.Content
+	if len(prompt.Context) > 0 {
+		parts := make([]*genai.Part, 0, len(prompt.Context)+1)
+		for _, text := range prompt.Context {
+			parts = append(parts, &genai.Part{Text: text})
 		}
-		parts = append(parts, &genai.Part{
-			InlineData: &genai.Blob{
-				MIMEType: "text/html", // Assuming it's HTML for /tmp/1.html
-				Data:     fileContent,
-			},
-		})
-	} else {
-		parts = append(parts, &genai.Part{Text: *assistantContent})
-	}
-
-	parts = append(parts, &genai.Part{Text: req.Body})
-
-	gr := []*genai.Content{
-		{
+		parts = append(parts, &genai.Part{Text: prompt.Question})
+		gr = append(gr, &genai.Content{
 			Role:  genai.RoleModel,
 			Parts: parts,
-		},
-		{
-			Role: genai.RoleUser,
-			Parts: []*genai.Part{
-				{Text: req.Body},
-			},
-		},
+		})
 	}
-	properties := req.GetGenaiSchemaProperties()
-	properties["IsCorrect"] = &genai.Schema{
-		Type:        genai.TypeBoolean,
-		Description: "The answer of the question",
+	gr = append(gr, &genai.Content{
+		Role: genai.RoleUser,
+		Parts: []*genai.Part{
+			{Text: prompt.Question},
+		},
+	})
+	responseSchema, err := req.ResponseSchema()
+	if err != nil {
+		return nil, err
 	}
 
 	config := &genai.GenerateContentConfig{
@@ -129,10 +117,10 @@ func geminiCall(ctx context.Context, model *models.GeminiModel, response *types.
 			IncludeThoughts: false,
 		},
 		ResponseMIMEType: "application/json",
-		ResponseSchema: &genai.Schema{
-			Type:       genai.TypeObject,
-			Properties: properties,
-		},
+		ResponseSchema:   responseSchema.Genai(),
+	}
+	if prompt.System != "" {
+		config.SystemInstruction = genai.NewContentFromText(prompt.System, genai.RoleUser)
 	}
 	result, err := client.Models.GenerateContent(
 		ctx,
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"NotificationManagement/config"
+	"NotificationManagement/domain"
+	"NotificationManagement/logger"
+	"NotificationManagement/models"
+	"NotificationManagement/utils/netguard"
+	"context"
+	"strings"
+)
+
+type HostRuleServiceImpl struct {
+	domain.CommonService[models.HostRule]
+	HostRuleRepo domain.HostRuleRepository
+	UserRepo     domain.UserRepository
+}
+
+func NewHostRuleService(repo domain.HostRuleRepository, userRepo domain.UserRepository) domain.HostRuleService {
+	service := &HostRuleServiceImpl{
+		HostRuleRepo: repo,
+		UserRepo:     userRepo,
+	}
+	service.CommonService = NewCommonService(repo, service)
+	return service
+}
+
+// PolicyFor builds the destination policy of a user from the rules of all of
+// their roles. Allow rules of different roles add up, a deny rule of any role wins.
+// userID must be the owner of the source, which is only ever taken from the
+// auth context, so a user can't fetch with the rules of someone else's roles.
+func (s *HostRuleServiceImpl) PolicyFor(ctx context.Context, userID uint) (*netguard.Policy, error) {
+	policy := &netguard.Policy{}
+	if allow := config.Fetch().AllowPrivateNetworks; allow != nil {
+		policy.AllowPrivate = *allow
+	}
+
+	user, err := s.UserRepo.GetByID(ctx, userID, nil)
+	if err != nil {
+		return nil, err
+	}
+	var roles []string
+	for _, role := range strings.Split(user.Roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	rules, err := s.HostRuleRepo.FindByRoles(ctx, roles)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		parsed, err := netguard.ParseRule(rule.Pattern)
+		if err != nil {
+			// Patterns are validated on write, so this only happens for rows edited by hand.
+			logger.Warn("Skipping invalid host rule", "id", rule.ID, "error", err)
+			continue
+		}
+		if rule.Action == models.HostRuleDeny {
+			policy.Deny = append(policy.Deny, parsed)
+		} else {
+			policy.Allow = append(policy.Allow, parsed)
+		}
+	}
+	return policy, nil
+}
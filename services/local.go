@@ -0,0 +1,105 @@
+package services
+
+import (
+	"NotificationManagement/domain"
+	"NotificationManagement/models"
+	"NotificationManagement/repositories"
+	"context"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+type LocalServiceImpl struct {
+	domain.CommonService[models.LocalModel]
+	CurlService domain.CurlService
+	Prompts     domain.PromptTemplateService
+}
+
+func NewLocalService(repo domain.LocalModelRepository, curl domain.CurlService, prompts domain.PromptTemplateService) domain.LocalService {
+	service := &LocalServiceImpl{
+		CurlService: curl,
+		Prompts:     prompts,
+	}
+	service.CommonService = NewCommonService(repo, service)
+	return service
+}
+
+func (s *LocalServiceImpl) ProcessContext(ctx context.Context) context.Context {
+	if txContext, ok := repositories.GetTxContext(ctx); ok {
+		filters := append(txContext.Filter, repositories.NewFilter("type", "=", s.GetModelType()))
+		txContext.Filter = filters
+	}
+	return ctx
+}
+
+func (s *LocalServiceImpl) MakeAIRequest(c context.Context, m *models.AIModel, requestId uint) (interface{}, error) {
+	curl, err := s.CurlService.GetModelById(c, requestId, nil)
+	if err != nil {
+		return nil, err
+	}
+	curlResponse, err := s.CurlService.ProcessCurlRequest(c, curl)
+	if err != nil {
+		return nil, err
+	}
+	model, err := s.GetModelById(c, m.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+	prompt, err := s.Prompts.BuildPrompt(c, curl, curlResponse)
+	if err != nil {
+		return nil, err
+	}
+	config := openai.DefaultConfig(model.GetAPIKey())
+	config.BaseURL = localBaseURL(model.GetBaseURL())
+	return openAICall(c, config, model.ModelName, prompt, curl)
+}
+
+func (s *LocalServiceImpl) GetAIJsonResponse(c context.Context, m *models.AIModel, requestId uint) (map[string]interface{}, error) {
+	request, err := s.MakeAIRequest(c, m, requestId)
+	if err != nil {
+		return nil, err
+	}
+	resp, _ := request.(*openai.ChatCompletionResponse)
+	return chatCompletionJSON(resp)
+}
+
+func (s *LocalServiceImpl) GetModelType() string {
+	return "local"
+}
+
+// localBaseURL accepts the server root as well as its /v1 prefix, since the
+// OpenAI-compatible servers all serve the API under /v1.
+func localBaseURL(baseURL string) string {
+	baseURL = strings.TrimRight(baseURL, "/")
+	if !strings.HasSuffix(baseURL, "/v1") {
+		baseURL += "/v1"
+	}
+	return baseURL
+}
+
+func (s *LocalServiceImpl) CreateAIModel(c context.Context, model any) error {
+	localModel := (model).(*models.LocalModel)
+	return s.CreateModel(c, localModel)
+}
+
+func (s *LocalServiceImpl) UpdateAIModel(c context.Context, model any) (any, error) {
+	localModel := (model).(*models.LocalModel)
+	return s.UpdateModel(c, localModel.ID, localModel)
+}
+
+func (s *LocalServiceImpl) GetAIModelById(ctx context.Context, id uint) (any, error) {
+	return s.GetModelById(ctx, id, nil)
+}
+
+func (s *LocalServiceImpl) GetAllAIModels(ctx context.Context) ([]any, error) {
+	allModels, err := s.GetAllModels(ctx, 100, 0)
+	if err != nil {
+		return nil, err
+	}
+	i := make([]any, len(allModels))
+	for idx, model := range allModels {
+		i[idx] = model
+	}
+	return i, err
+}
@@ -14,7 +14,7 @@ func NewSMSNotifier() *SMSNotifier {
 }
 
 func (s *SMSNotifier) Send(ctx context.Context, notification *types.Notification) error {
-	logger.Info(fmt.Sprintf("SMS =>To: %s, Message: %s", notification.UserId, notification.Message))
+	logger.Info(fmt.Sprintf("SMS =>To: %d, Message: %s", notification.UserId, notification.Message))
 	return nil
 }
 
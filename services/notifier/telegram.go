@@ -30,10 +30,10 @@ func (t *TelegramNotifierImpl) Send(ctx context.Context, notification *types.Not
 	//TODO : have create an call  worker
 	if *config.Telegram().Enabled {
 		t.TelegramAPI.SendMessage(chatID, notification.Message, nil)
-		logger.Info(fmt.Sprintf("[Telegram] To: %s, Message: %s", chatID, notification.Message), notification)
+		logger.Info(fmt.Sprintf("[Telegram] To: %d, Message: %s", chatID, notification.Message), notification)
 	}
 
-	logger.Info(fmt.Sprintf("[Telegram] To: %s, Message: %s", chatID, notification.Message), notification)
+	logger.Info(fmt.Sprintf("[Telegram] To: %d, Message: %s", chatID, notification.Message), notification)
 
 	return nil
 }
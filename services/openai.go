@@ -8,6 +8,7 @@ import (
 	"NotificationManagement/utils/errutil"
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/sashabaranov/go-openai"
 )
@@ -15,11 +16,13 @@ import (
 type OpenAIServiceImpl struct {
 	domain.CommonService[models.OpenAIModel]
 	CurlService domain.CurlService
+	Prompts     domain.PromptTemplateService
 }
 
-func NewOpenAIService(repo domain.OpenAIModelRepository, curl domain.CurlService) domain.OpenAIService {
+func NewOpenAIService(repo domain.OpenAIModelRepository, curl domain.CurlService, prompts domain.PromptTemplateService) domain.OpenAIService {
 	service := &OpenAIServiceImpl{
 		CurlService: curl,
+		Prompts:     prompts,
 	}
 	service.CommonService = NewCommonService(repo, service)
 	return service
@@ -46,7 +49,15 @@ func (s *OpenAIServiceImpl) MakeAIRequest(c context.Context, m *models.AIModel,
 	if err != nil {
 		return nil, err
 	}
-	respBody, err := openAICall(c, model, curlResponse, curl)
+	prompt, err := s.Prompts.BuildPrompt(c, curl, curlResponse)
+	if err != nil {
+		return nil, err
+	}
+	config := openai.DefaultConfig(model.GetAPIKey())
+	if model.GetBaseURL() != "" {
+		config.BaseURL = model.GetBaseURL()
+	}
+	respBody, err := openAICall(c, config, model.ModelName, prompt, curl)
 	if err != nil {
 		return nil, err
 	}
@@ -59,15 +70,17 @@ func (s *OpenAIServiceImpl) GetAIJsonResponse(c context.Context, m *models.AIMod
 		return nil, err
 	}
 	resp, _ := request.(*openai.ChatCompletionResponse)
-	var aiResp map[string]interface{}
+	return chatCompletionJSON(resp)
+}
+
+// chatCompletionJSON decodes the structured answer of a chat completion.
+func chatCompletionJSON(resp *openai.ChatCompletionResponse) (map[string]interface{}, error) {
 	if len(resp.Choices) == 0 {
-		return map[string]interface{}{
-			"comment": "Failed",
-		}, nil
-	} else if err := json.Unmarshal([]byte(resp.Choices[0].Message.Content), &aiResp); err != nil {
-		return map[string]interface{}{
-			"comment": resp.Choices[0].Message.Content,
-		}, nil
+		return nil, errutil.NewAppError(errutil.ErrExternalServiceError, fmt.Errorf("%w: no choices in the completion", types.ErrInvalidAIOutput))
+	}
+	var aiResp map[string]interface{}
+	if err := json.Unmarshal([]byte(resp.Choices[0].Message.Content), &aiResp); err != nil {
+		return nil, errutil.NewAppError(errutil.ErrExternalServiceError, fmt.Errorf("%w: %w", types.ErrInvalidAIOutput, err))
 	}
 	return aiResp, nil
 }
@@ -76,75 +89,38 @@ func (s *OpenAIServiceImpl) GetModelType() string {
 	return "openai"
 }
 
-// createJSONSchema creates a JSON schema from the CurlRequest additional fields
-func createJSONSchema(req *models.CurlRequest) types.JSONSchema {
-	properties := make(map[string]types.JSONSchemaProperty)
-	required := []string{"IsCorrect"}
-
-	properties["IsCorrect"] = types.JSONSchemaProperty{
-		Type:        "boolean",
-		Description: "Indicates whether the response is correct",
-	}
-
-	// Add properties from additional fields
-	if req.AdditionalFields != nil {
-		for _, field := range *req.AdditionalFields {
-			var schemaType string
-			switch field.Type {
-			case "number":
-				schemaType = "number"
-			case "boolean":
-				schemaType = "boolean"
-			default:
-				schemaType = "string"
-			}
-
-			properties[field.PropertyName] = types.JSONSchemaProperty{
-				Type:        schemaType,
-				Description: field.Description,
-			}
-			required = append(required, field.PropertyName)
-		}
-	}
-
-	return types.JSONSchema{
-		Type:                 "object",
-		Properties:           properties,
-		Required:             required,
-		AdditionalProperties: false,
-	}
-}
-
-func openAICall(ctx context.Context, model *models.OpenAIModel, response *types.CurlResponse, req *models.CurlRequest) (*openai.ChatCompletionResponse, error) {
-	assistantContent, err := response.GetAssistantContent(req.ResponseType)
-	if err != nil {
-		return nil, err
-	}
-
-	config := openai.DefaultConfig(model.GetAPIKey())
-	if model.GetBaseURL() != "" {
-		config.BaseURL = model.GetBaseURL()
-	}
+// openAICall asks any chat completions endpoint for a structured answer.
+func openAICall(ctx context.Context, config openai.ClientConfig, modelName string, prompt *types.Prompt, req *models.CurlRequest) (*openai.ChatCompletionResponse, error) {
 	client := openai.NewClientWithConfig(config)
 
-	messages := []openai.ChatCompletionMessage{
-		{
+	var messages []openai.ChatCompletionMessage
+	if prompt.System != "" {
+		messages = append(messages, openai.ChatCompletionMessage{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: prompt.System,
+		})
+	}
+	for _, text := range prompt.Context {
+		messages = append(messages, openai.ChatCompletionMessage{
 			Role:    openai.ChatMessageRoleAssistant,
-			Content: *assistantContent,
-		},
-		{
-			Role:    openai.ChatMessageRoleUser,
-			Content: req.Body,
-		},
+			Content: text,
+		})
 	}
+	messages = append(messages, openai.ChatCompletionMessage{
+		Role:    openai.ChatMessageRoleUser,
+		Content: prompt.Question,
+	})
 
 	// Create JSON schema from additional fields for structured output
-	jsonSchema := createJSONSchema(req)
+	responseSchema, err := req.ResponseSchema()
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := client.CreateChatCompletion(
 		ctx,
 		openai.ChatCompletionRequest{
-			Model:    model.ModelName,
+			Model:    modelName,
 			Messages: messages,
 			Stream:   false,
 			ResponseFormat: &openai.ChatCompletionResponseFormat{
@@ -152,7 +128,7 @@ func openAICall(ctx context.Context, model *models.OpenAIModel, response *types.
 				JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
 					Name:        "response_schema",
 					Description: "Structured response with required fields",
-					Schema:      &jsonSchema, // Pass a pointer to jsonSchema
+					Schema:      responseSchema.StrictJSONSchema(),
 					Strict:      true,
 				},
 			},
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"NotificationManagement/domain"
+	"NotificationManagement/logger"
+	"NotificationManagement/models"
+	"NotificationManagement/types"
+	"NotificationManagement/utils/errutil"
+	"NotificationManagement/utils/prompt"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type PromptTemplateServiceImpl struct {
+	domain.CommonService[models.PromptTemplate]
+	TemplateRepo domain.PromptTemplateRepository
+	SnapshotRepo domain.ResponseSnapshotRepository
+}
+
+func NewPromptTemplateService(repo domain.PromptTemplateRepository, snapshotRepo domain.ResponseSnapshotRepository) domain.PromptTemplateService {
+	service := &PromptTemplateServiceImpl{
+		TemplateRepo: repo,
+		SnapshotRepo: snapshotRepo,
+	}
+	service.CommonService = NewCommonService(repo, service)
+	return service
+}
+
+// GetUserTemplate returns the template with all its versions, oldest first.
+func (s *PromptTemplateServiceImpl) GetUserTemplate(ctx context.Context, userID uint, id uint) (*models.PromptTemplate, error) {
+	template, err := s.GetModelById(ctx, id, &[]string{"Versions"})
+	if err != nil {
+		return nil, err
+	}
+	if template.UserID != userID {
+		return nil, errutil.NewAppError(errutil.ErrRecordNotFound, fmt.Errorf("prompt template %d not found", id))
+	}
+	if template.Versions != nil {
+		sort.Slice(*template.Versions, func(i, j int) bool {
+			return (*template.Versions)[i].Version < (*template.Versions)[j].Version
+		})
+	}
+	return template, nil
+}
+
+func (s *PromptTemplateServiceImpl) GetUserTemplates(ctx context.Context, userID uint, limit, offset int) ([]models.PromptTemplate, error) {
+	return s.TemplateRepo.GetAllByUser(ctx, userID, limit, offset)
+}
+
+func (s *PromptTemplateServiceImpl) CreateTemplate(ctx context.Context, template *models.PromptTemplate, version *models.PromptTemplateVersion) error {
+	version.Version = 1
+	template.LatestVersion = 1
+	template.Versions = &[]models.PromptTemplateVersion{*version}
+	return s.CreateModel(ctx, template)
+}
+
+// UpdateTemplate renames the template and adds version as its next version,
+// unless the text is the same as the latest one.
+func (s *PromptTemplateServiceImpl) UpdateTemplate(ctx context.Context, userID uint, id uint, name string, version *models.PromptTemplateVersion) (*models.PromptTemplate, error) {
+	template, err := s.GetUserTemplate(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := s.TemplateRepo.GetVersion(ctx, id, template.LatestVersion)
+	if err != nil {
+		return nil, err
+	}
+	if latest.System == version.System && latest.User == version.User {
+		if _, err := s.UpdateModel(ctx, id, &models.PromptTemplate{UserID: userID, Name: name}); err != nil {
+			return nil, err
+		}
+	} else if err := s.TemplateRepo.AddVersion(ctx, id, name, version); err != nil {
+		return nil, err
+	}
+	return s.GetUserTemplate(ctx, userID, id)
+}
+
+func (s *PromptTemplateServiceImpl) CheckTemplateRef(ctx context.Context, userID uint, req *models.CurlRequest) error {
+	if req.PromptTemplateID == nil {
+		return nil
+	}
+	template, err := s.GetModelById(ctx, *req.PromptTemplateID, nil)
+	if err != nil || template.UserID != userID {
+		return errutil.NewAppError(errutil.ErrInvalidRequestBody, fmt.Errorf("prompt template %d not found", *req.PromptTemplateID))
+	}
+	if req.PromptVersion != nil {
+		if _, err := s.TemplateRepo.GetVersion(ctx, template.ID, *req.PromptVersion); err != nil {
+			return errutil.NewAppError(errutil.ErrInvalidRequestBody, fmt.Errorf("prompt template %d has no version %d", template.ID, *req.PromptVersion))
+		}
+	}
+	return nil
+}
+
+func (s *PromptTemplateServiceImpl) BuildPrompt(ctx context.Context, req *models.CurlRequest, resp *types.CurlResponse) (*types.Prompt, error) {
+	content, err := resp.GetAssistantContent(req.ResponseType)
+	if err != nil {
+		return nil, err
+	}
+	if req.PromptTemplateID == nil {
+		p := &types.Prompt{Context: []string{*content}, Question: req.Body}
+		if diff := resp.GetDiffContent(); diff != nil {
+			p.Context = append(p.Context, *diff)
+		}
+		return p, nil
+	}
+
+	template, err := s.GetModelById(ctx, *req.PromptTemplateID, nil)
+	if err != nil || template.UserID != req.UserID {
+		return nil, errutil.NewAppError(errutil.ErrRecordNotFound, fmt.Errorf("prompt template %d not found", *req.PromptTemplateID))
+	}
+	number := template.LatestVersion
+	if req.PromptVersion != nil {
+		number = *req.PromptVersion
+	}
+	version, err := s.TemplateRepo.GetVersion(ctx, template.ID, number)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &prompt.Data{
+		URL:      req.URL,
+		Method:   req.Method,
+		Response: snapshotBody(resp.Body),
+		Previous: s.previousBody(ctx, req, resp),
+		Diff:     resp.Diff,
+		Message:  reminderMessage(ctx),
+		Fields:   promptFields(req),
+		Now:      time.Now(),
+	}
+	system, err := prompt.Render("system", version.System, data)
+	if err != nil {
+		return nil, errutil.NewAppError(errutil.ErrPromptRender, err)
+	}
+	question, err := prompt.Render("user", version.User, data)
+	if err != nil {
+		return nil, errutil.NewAppError(errutil.ErrPromptRender, err)
+	}
+	return &types.Prompt{System: system, Question: question}, nil
+}
+
+// previousBody returns the most recent snapshot that differs from resp.
+func (s *PromptTemplateServiceImpl) previousBody(ctx context.Context, req *models.CurlRequest, resp *types.CurlResponse) string {
+	snapshots, err := s.SnapshotRepo.GetLatest(ctx, req.ID, 2)
+	if err != nil {
+		logger.Warn("Failed to load previous snapshot for prompt", "request_id", req.ID, "error", err)
+		return ""
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.ContentHash != resp.ContentHash {
+			return snapshot.Body
+		}
+	}
+	return ""
+}
+
+func promptFields(req *models.CurlRequest) []prompt.Field {
+	if req.AdditionalFields == nil {
+		return nil
+	}
+	fields := make([]prompt.Field, 0, len(*req.AdditionalFields))
+	for _, f := range *req.AdditionalFields {
+		fields = append(fields, prompt.Field{
+			Name:        f.PropertyName,
+			Type:        f.Type,
+			Description: f.Description,
+			Required:    f.Required == nil || *f.Required,
+		})
+	}
+	return fields
+}
+
+type reminderMessageKey struct{}
+
+// withReminderMessage makes the message of the running reminder available to prompt templates.
+func withReminderMessage(ctx context.Context, message string) context.Context {
+	return context.WithValue(ctx, reminderMessageKey{}, message)
+}
+
+func reminderMessage(ctx context.Context) string {
+	message, _ := ctx.Value(reminderMessageKey{}).(string)
+	return message
+}
@@ -5,54 +5,166 @@ import (
 	"NotificationManagement/logger"
 	"NotificationManagement/models"
 	"NotificationManagement/types"
+	"NotificationManagement/utils/errutil"
 	"context"
-	"fmt"
+	"errors"
+	"net/http"
+	"time"
 )
 
 type ReminderServiceImpl struct {
 	domain.CommonService[models.Reminder]
 	domain.NotificationDispatcher
 	domain.AiDispatcher
+	ReminderRepo domain.ReminderRepository
+	RunRepo      domain.ReminderRunRepository
+	FeedRepo     domain.FeedEntryRepository
+	SnapshotRepo domain.ResponseSnapshotRepository
+	CurlService  domain.CurlService
 }
 
-func NewReminderService(repo domain.ReminderRepository, dispatcher domain.NotificationDispatcher, aiDispatcher domain.AiDispatcher) domain.ReminderService {
+func NewReminderService(repo domain.ReminderRepository, runRepo domain.ReminderRunRepository, feedRepo domain.FeedEntryRepository, snapshotRepo domain.ResponseSnapshotRepository, dispatcher domain.NotificationDispatcher, aiDispatcher domain.AiDispatcher, curlService domain.CurlService) domain.ReminderService {
 	service := &ReminderServiceImpl{
 		NotificationDispatcher: dispatcher,
 		AiDispatcher:           aiDispatcher,
+		ReminderRepo:           repo,
+		RunRepo:                runRepo,
+		FeedRepo:               feedRepo,
+		SnapshotRepo:           snapshotRepo,
+		CurlService:            curlService,
 	}
 	service.CommonService = NewCommonService(repo, service)
 	return service
 }
+
 func (a *ReminderServiceImpl) ProcessAndSendReminders(ctx context.Context, reminderId uint) error {
+	run := &models.ReminderRun{ReminderID: reminderId, StartedAt: time.Now()}
+	change, err := a.processReminder(ctx, reminderId, run)
+	if err == nil && change != nil {
+		a.keepRunState(ctx, reminderId, change)
+	}
+	a.recordRun(ctx, run, err)
+	return err
+}
+
+// keepRunState remembers what a successful run saw of its source, so the
+// next run only reports what changed since. A failed run keeps the old state
+// and sees the same change again.
+// The run has already notified by then, so failing it here would only make
+// the task retry and notify twice.
+func (a *ReminderServiceImpl) keepRunState(ctx context.Context, reminderId uint, change *types.SourceChange) {
+	state := change.State
+	if err := a.ReminderRepo.UpdateFetchState(ctx, reminderId, state.ContentHash, state.ETag, state.LastModified); err != nil {
+		logger.Warn("Failed to store fetch state", "reminder_id", reminderId, "error", err)
+	}
+	if change.FeedGUIDs != nil {
+		if err := a.FeedRepo.ReplaceSeen(ctx, reminderId, change.NewFeedGUIDs, change.FeedGUIDs); err != nil {
+			logger.Warn("Failed to store seen feed items", "reminder_id", reminderId, "error", err)
+		}
+	}
+}
+
+// recordRun stores the outcome of a run. Fetch failures keep their kind, status
+// and attempt count so broken sources can be told apart from AI or notify errors.
+func (a *ReminderServiceImpl) recordRun(ctx context.Context, run *models.ReminderRun, err error) {
+	run.FinishedAt = time.Now()
+	if run.Status == "" {
+		run.Status = models.ReminderRunSucceeded
+	}
+	if err != nil {
+		run.Status = models.ReminderRunFailed
+		run.ErrorMessage = err.Error()
 
-	reminder, err := a.CommonService.GetModelById(ctx, reminderId, &[]string{"Request", "Request.User", "Request.Models", "Request.Models.AiModel", "Request.User.Telegram"})
+		var fetchErr *types.FetchError
+		var appErr *errutil.AppError
+		if errors.As(err, &fetchErr) {
+			run.ErrorType = string(fetchErr.Kind)
+			run.StatusCode = fetchErr.StatusCode
+			run.Attempts = fetchErr.Attempts
+		} else if errors.As(err, &appErr) {
+			run.ErrorType = appErr.Code.Code
+		}
+	}
+	if err := a.RunRepo.Create(ctx, run); err != nil {
+		logger.Error("Failed to record reminder run", "error", err, "reminder_id", run.ReminderID)
+	}
+}
+
+func (a *ReminderServiceImpl) processReminder(ctx context.Context, reminderId uint, run *models.ReminderRun) (*types.SourceChange, error) {
+	reminder, err := a.CommonService.GetModelById(ctx, reminderId, &[]string{"Request", "Request.Steps", "Request.User", "Request.Models", "Request.Models.AiModel", "Request.User.Telegram"})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	for _, model := range *reminder.Request.Models {
-		processor, err := a.AiDispatcher.RequestProcessor(ctx, model.AiModel, reminder.RequestID)
+
+	ctx = withReminderMessage(ctx, reminder.Message)
+	rule, err := compileRule(reminder)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := a.CurlService.CheckForChanges(ctx, reminder.Request, types.FetchState{
+		ContentHash:  reminder.ContentHash,
+		ETag:         reminder.ETag,
+		LastModified: reminder.LastModified,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reminder.SkipIfUnchanged && reminder.Request.ResponseType == types.ResponseTypeFeed && change.Response.Status != http.StatusNotModified {
+		if err := a.keepNewFeedItems(ctx, reminder, change); err != nil {
+			return nil, err
+		}
+	}
+	if !change.Changed && reminder.SkipIfUnchanged {
+		logger.Info("Source unchanged since last run, skipping", "reminder_id", reminder.ID, "request_id", reminder.RequestID)
+		run.Status = models.ReminderRunSkipped
+		return change, nil
+	}
+	resp := change.Response
+	if resp.Status == http.StatusNotModified {
+		// A 304 carries no body; fetch it once here rather than in every AI service.
+		resp, err = a.CurlService.ProcessCurlRequest(ctx, reminder.Request)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		logger.Debug("ReminderServiceImpl.ProcessAndSendReminders", "processor", processor)
-		if isCorrect, ok := processor["IsCorrect"].(bool); ok && isCorrect {
-
-			var message string
-			for key, value := range processor {
-				if key != "IsCorrect" {
-					message += key + ": " + fmt.Sprintf("%v", value) + "\n"
-				}
-			}
-			return a.NotificationDispatcher.Notify(ctx, &types.Notification{
-				Subject:  reminder.Message,
-				Message:  message,
-				Channels: []string{"sms", "email", "telegram"},
-				UserId:   reminder.Request.UserID,
-				User:     reminder.Request.User,
-			})
+	}
+	ctx = a.CurlService.WithPrefetched(ctx, reminder.RequestID, resp)
+
+	if reminder.RuleMode == models.RuleModeRules || reminder.RuleMode == models.RuleModeGate {
+		matched, err := evalRule(ctx, rule, reminder.Request.ResponseType, resp, nil)
+		if err != nil {
+			return nil, err
+		}
+		logger.Debug("Reminder rule evaluated", "reminder_id", reminder.ID, "rule", reminder.Rule, "matched", matched)
+		if !matched {
+			return change, nil
 		}
+		if reminder.RuleMode == models.RuleModeRules {
+			return change, a.notify(ctx, reminder, "Rule matched: "+reminder.Rule+"\n")
+		}
+	}
 
+	verdicts := a.askModels(ctx, reminder, rule, resp)
+	for _, v := range verdicts {
+		run.Verdicts = append(run.Verdicts, v.record)
 	}
+	isCorrect, err := decide(reminder.Strategy, verdicts)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("Reminder verdicts combined", "reminder_id", reminder.ID, "strategy", reminder.Strategy, "verdicts", len(verdicts), "is_correct", isCorrect)
+	if isCorrect {
+		return change, a.notify(ctx, reminder, consensusMessage(verdicts))
+	}
+	return change, nil
+}
 
-	return nil
+func (a *ReminderServiceImpl) notify(ctx context.Context, reminder *models.Reminder, message string) error {
+	return a.NotificationDispatcher.Notify(ctx, &types.Notification{
+		Subject:  reminder.Message,
+		Message:  message,
+		Channels: []string{"sms", "email", "telegram"},
+		UserId:   reminder.Request.UserID,
+		User:     reminder.Request.User,
+	})
 }
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"NotificationManagement/logger"
+	"NotificationManagement/models"
+	"NotificationManagement/types"
+	"NotificationManagement/utils/rules"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// verdict is a model's recorded verdict together with the answer it was based on.
+type verdict struct {
+	record models.ReminderVerdict
+	answer map[string]interface{}
+	err    error
+}
+
+// askModels collects the verdict of every model of the reminder's request. A
+// model that fails is recorded with its error and abstains. With the first
+// positive strategy the remaining models aren't asked once one says yes.
+func (a *ReminderServiceImpl) askModels(ctx context.Context, reminder *models.Reminder, rule *rules.Rule, resp *types.CurlResponse) []verdict {
+	var verdicts []verdict
+	tried := make(map[uint]bool)
+	for _, chain := range modelChains(*reminder.Request.Models) {
+		chain = untried(chain, tried)
+		answer, model, err := a.AiDispatcher.RequestChain(ctx, chain, reminder.RequestID)
+		for _, m := range chain {
+			tried[m.AiModelID] = true
+			if m.AiModelID == model.AiModelID {
+				break
+			}
+		}
+		v := verdict{record: models.ReminderVerdict{AiModelID: model.AiModelID, Weight: model.Weight}}
+		if model.AiModelID != chain[0].AiModelID {
+			v.record.FallbackFrom = &chain[0].AiModelID
+		}
+		if err == nil {
+			logger.Debug("ReminderServiceImpl.askModels", "ai_model_id", model.AiModelID, "processor", answer)
+			v.answer, v.record.IsCorrect, err = a.judge(ctx, reminder, rule, resp, answer)
+		}
+		if err != nil {
+			logger.Warn("AI model failed, continuing without its verdict", "reminder_id", reminder.ID, "ai_model_id", model.AiModelID, "error", err)
+			v.err = err
+			v.record.Error = err.Error()
+		} else if answer, err := json.Marshal(v.answer); err == nil {
+			v.record.Answer = string(answer)
+		}
+		verdicts = append(verdicts, v)
+
+		if v.record.IsCorrect && (reminder.Strategy == "" || reminder.Strategy == models.StrategyFirstPositive) {
+			break
+		}
+	}
+	return verdicts
+}
+
+// modelChains orders the models by priority and gives every model that isn't
+// a fallback a chain: the model itself followed by the fallback models after
+// it. The first model always gets a chain.
+func modelChains(requestModels []models.RequestAIModel) [][]models.RequestAIModel {
+	ordered := append([]models.RequestAIModel(nil), requestModels...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Priority < ordered[j].Priority
+	})
+
+	var chains [][]models.RequestAIModel
+	for i, model := range ordered {
+		if model.Fallback && i > 0 {
+			continue
+		}
+		chain := []models.RequestAIModel{model}
+		for _, next := range ordered[i+1:] {
+			if next.Fallback {
+				chain = append(chain, next)
+			}
+		}
+		chains = append(chains, chain)
+	}
+	return chains
+}
+
+// untried drops the fallbacks of chain that an earlier chain already tried, so
+// a fallback model stands in for one failed model at most.
+func untried(chain []models.RequestAIModel, tried map[uint]bool) []models.RequestAIModel {
+	kept := []models.RequestAIModel{chain[0]}
+	for _, model := range chain[1:] {
+		if !tried[model.AiModelID] {
+			kept = append(kept, model)
+		}
+	}
+	return kept
+}
+
+// judge turns a model's answer into its verdict.
+func (a *ReminderServiceImpl) judge(ctx context.Context, reminder *models.Reminder, rule *rules.Rule, resp *types.CurlResponse, answer map[string]interface{}) (map[string]interface{}, bool, error) {
+	if reminder.RuleMode == models.RuleModeOutput {
+		isCorrect, err := evalRule(ctx, rule, reminder.Request.ResponseType, resp, answer)
+		return answer, isCorrect, err
+	}
+	isCorrect, _ := answer["IsCorrect"].(bool)
+	return answer, isCorrect, nil
+}
+
+// decide combines the verdicts with strategy. It fails only when there were
+// models to ask and none of them answered.
+func decide(strategy string, verdicts []verdict) (bool, error) {
+	var answered, positive int
+	var weight, positiveWeight float64
+	var firstErr error
+	for _, v := range verdicts {
+		if v.err != nil {
+			if firstErr == nil {
+				firstErr = v.err
+			}
+			continue
+		}
+		answered++
+		weight += v.record.Weight
+		if v.record.IsCorrect {
+			positive++
+			positiveWeight += v.record.Weight
+		}
+	}
+	if answered == 0 {
+		return false, firstErr
+	}
+
+	switch strategy {
+	case models.StrategyMajority:
+		return positive*2 > answered, nil
+	case models.StrategyUnanimous:
+		return positive == answered, nil
+	case models.StrategyWeighted:
+		return weight > 0 && positiveWeight*2 > weight, nil
+	default:
+		return positive > 0, nil
+	}
+}
+
+// consensusMessage lists the answers of the models that said yes, each under
+// its model id when there is more than one.
+func consensusMessage(verdicts []verdict) string {
+	var positives []verdict
+	for _, v := range verdicts {
+		if v.err == nil && v.record.IsCorrect {
+			positives = append(positives, v)
+		}
+	}
+
+	var message string
+	for _, v := range positives {
+		if len(positives) > 1 {
+			message += fmt.Sprintf("Model %d:\n", v.record.AiModelID)
+		}
+		keys := make([]string, 0, len(v.answer))
+		for key := range v.answer {
+			if key != "IsCorrect" {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			message += key + ": " + fmt.Sprintf("%v", v.answer[key]) + "\n"
+		}
+	}
+	return message
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"NotificationManagement/models"
+	"NotificationManagement/types"
+	"context"
+)
+
+// keepNewFeedItems narrows a feed response to the items the reminder hasn't
+// reported on a previous run and reports whether there were any. The GUIDs are
+// only remembered once the run succeeded, see keepRunState.
+func (a *ReminderServiceImpl) keepNewFeedItems(ctx context.Context, reminder *models.Reminder, change *types.SourceChange) error {
+	resp := change.Response
+	body, ok := resp.Body.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	items, ok := body["items"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	guids := make([]string, 0, len(items))
+	for _, item := range items {
+		if guid := feedItemGUID(item); guid != "" {
+			guids = append(guids, guid)
+		}
+	}
+	seen, err := a.FeedRepo.GetSeenGUIDs(ctx, reminder.ID, guids)
+	if err != nil {
+		return err
+	}
+	seenSet := make(map[string]bool, len(seen))
+	for _, guid := range seen {
+		seenSet[guid] = true
+	}
+
+	newItems := make([]interface{}, 0)
+	var added []string
+	for _, item := range items {
+		guid := feedItemGUID(item)
+		if guid != "" && seenSet[guid] {
+			continue
+		}
+		newItems = append(newItems, item)
+		if guid != "" {
+			added = append(added, guid)
+			seenSet[guid] = true
+		}
+	}
+
+	narrowed := make(map[string]interface{}, len(body))
+	for k, v := range body {
+		narrowed[k] = v
+	}
+	narrowed["items"] = newItems
+	resp.Body = narrowed
+	change.Changed = len(newItems) > 0
+	change.FeedGUIDs = guids
+	change.NewFeedGUIDs = added
+	return nil
+}
+
+// feedItemGUID returns the GUID of a feed item, or "" when the extractor turned
+// the item into something other than an object.
+func feedItemGUID(item interface{}) string {
+	m, ok := item.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	guid, _ := m["guid"].(string)
+	return guid
+}
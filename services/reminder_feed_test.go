@@ -0,0 +1,64 @@
+package services
+
+import (
+	"NotificationManagement/domain"
+	"NotificationManagement/models"
+	"NotificationManagement/types"
+	"context"
+	"reflect"
+	"testing"
+)
+
+type stubFeedRepo struct {
+	domain.FeedEntryRepository
+	seen map[uint][]string
+}
+
+func (r *stubFeedRepo) GetSeenGUIDs(ctx context.Context, reminderID uint, guids []string) ([]string, error) {
+	return r.seen[reminderID], nil
+}
+
+func TestKeepNewFeedItemsWithoutObjects(t *testing.T) {
+	service := &ReminderServiceImpl{FeedRepo: &stubFeedRepo{seen: map[uint][]string{3: {"a"}}}}
+	reminder := &models.Reminder{}
+	reminder.ID = 3
+	change := &types.SourceChange{Response: &types.CurlResponse{Body: map[string]interface{}{
+		"title": "Example",
+		"items": []interface{}{
+			"First post",
+			42.0,
+			nil,
+			map[string]interface{}{"guid": "a", "title": "Seen"},
+			map[string]interface{}{"guid": "b", "title": "New"},
+			map[string]interface{}{"title": "No guid"},
+		},
+	}}}
+
+	if err := service.keepNewFeedItems(context.Background(), reminder, change); err != nil {
+		t.Fatalf("keepNewFeedItems: %v", err)
+	}
+
+	body := change.Response.Body.(map[string]interface{})
+	want := []interface{}{
+		"First post",
+		42.0,
+		nil,
+		map[string]interface{}{"guid": "b", "title": "New"},
+		map[string]interface{}{"title": "No guid"},
+	}
+	if !reflect.DeepEqual(body["items"], want) {
+		t.Errorf("items = %v, want %v", body["items"], want)
+	}
+	if body["title"] != "Example" {
+		t.Errorf("title = %v, want the feed's other fields kept", body["title"])
+	}
+	if !change.Changed {
+		t.Error("Changed = false, want true")
+	}
+	if !reflect.DeepEqual(change.FeedGUIDs, []string{"a", "b"}) {
+		t.Errorf("FeedGUIDs = %v, want [a b]", change.FeedGUIDs)
+	}
+	if !reflect.DeepEqual(change.NewFeedGUIDs, []string{"b"}) {
+		t.Errorf("NewFeedGUIDs = %v, want [b]", change.NewFeedGUIDs)
+	}
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"NotificationManagement/models"
+	"NotificationManagement/types"
+	"NotificationManagement/utils/errutil"
+	"NotificationManagement/utils/rules"
+	"context"
+	"fmt"
+)
+
+// compileRule returns nil for reminders that leave the decision to the AI.
+func compileRule(reminder *models.Reminder) (*rules.Rule, error) {
+	if reminder.RuleMode == "" {
+		return nil, nil
+	}
+	rule, err := rules.Compile(reminder.Rule)
+	if err != nil {
+		return nil, errutil.NewAppError(errutil.ErrRuleEvaluation, err)
+	}
+	return rule, nil
+}
+
+// evalRule runs the rule against the response as the AI would see it, after
+// extraction. XML bodies are parsed into maps first; fields holds the AI's
+// answer in output mode.
+func evalRule(ctx context.Context, rule *rules.Rule, responseType string, resp *types.CurlResponse, fields map[string]interface{}) (bool, error) {
+	body := resp.Body
+	if xml, ok := body.(string); ok && responseType == types.ResponseTypeXML {
+		parsed, err := rules.ParseXML(xml)
+		if err != nil {
+			return false, errutil.NewAppError(errutil.ErrRuleEvaluation, fmt.Errorf("parse xml response: %w", err))
+		}
+		body = parsed
+	}
+	matched, err := rule.Eval(ctx, rules.Input{
+		Response: body,
+		Status:   resp.Status,
+		Headers:  resp.Headers,
+		Fields:   fields,
+	})
+	if err != nil {
+		return false, errutil.NewAppError(errutil.ErrRuleEvaluation, err)
+	}
+	return matched, nil
+}
+
+// CheckRule tries the rule of a reminder on an XML source against the latest
+// response of the source, so a rule that can't work on the parsed XML, such as
+// comparing text with a number, is rejected when it is saved rather than failing
+// every run. Output mode rules need the AI's answer and aren't tried.
+func (a *ReminderServiceImpl) CheckRule(ctx context.Context, reminder *models.Reminder) error {
+	if reminder.RuleMode == "" || reminder.RuleMode == models.RuleModeOutput {
+		return nil
+	}
+	req, err := a.CurlService.GetModelById(ctx, reminder.RequestID, nil)
+	if err != nil {
+		return err
+	}
+	if req.ResponseType != types.ResponseTypeXML {
+		return nil
+	}
+	rule, err := compileRule(reminder)
+	if err != nil {
+		return err
+	}
+
+	var resp *types.CurlResponse
+	snapshots, err := a.SnapshotRepo.GetLatest(ctx, req.ID, 1)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) > 0 {
+		resp = &types.CurlResponse{Status: snapshots[0].StatusCode, Headers: map[string]string{}, Body: snapshots[0].Body}
+	} else if resp, err = a.CurlService.ProcessCurlRequest(ctx, req); err != nil {
+		return err
+	}
+	if _, err := evalRule(ctx, rule, req.ResponseType, resp, nil); err != nil {
+		return errutil.NewAppError(errutil.ErrInvalidRequestBody, fmt.Errorf("rule fails on the latest xml response of the source: %w", err))
+	}
+	return nil
+}
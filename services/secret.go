@@ -0,0 +1,82 @@
+package services
+
+import (
+	"NotificationManagement/domain"
+	"NotificationManagement/models"
+	"NotificationManagement/utils/errutil"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+type SecretServiceImpl struct {
+	domain.CommonService[models.Secret]
+	SecretRepo domain.SecretRepository
+}
+
+func NewSecretService(repo domain.SecretRepository) domain.SecretService {
+	service := &SecretServiceImpl{
+		SecretRepo: repo,
+	}
+	service.CommonService = NewCommonService(repo, service)
+	return service
+}
+
+func (s *SecretServiceImpl) GetUserSecret(ctx context.Context, userID uint, id uint) (*models.Secret, error) {
+	secret, err := s.GetModelById(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	if secret.UserID != userID {
+		return nil, errutil.NewAppError(errutil.ErrRecordNotFound, fmt.Errorf("secret %d not found", id))
+	}
+	return secret, nil
+}
+
+func (s *SecretServiceImpl) GetUserSecrets(ctx context.Context, userID uint, limit, offset int) ([]models.Secret, error) {
+	return s.SecretRepo.GetAllByUser(ctx, userID, limit, offset)
+}
+
+// Expander returns a function that renders {{secret "name"}} placeholders with
+// the user's decrypted secrets and {{var "name"}} placeholders with vars, which
+// may keep growing while the function is in use. Secret lookups are cached for
+// the lifetime of the function.
+func (s *SecretServiceImpl) Expander(ctx context.Context, userID uint, vars map[string]string) func(string) (string, error) {
+	cache := map[string]string{}
+	funcs := template.FuncMap{
+		"secret": func(name string) (string, error) {
+			if value, ok := cache[name]; ok {
+				return value, nil
+			}
+			secret, err := s.SecretRepo.FindByUserAndName(ctx, userID, name)
+			if err != nil {
+				return "", fmt.Errorf("secret %q not found", name)
+			}
+			cache[name] = string(secret.Value)
+			return cache[name], nil
+		},
+		"var": func(name string) (string, error) {
+			value, ok := vars[name]
+			if !ok {
+				return "", fmt.Errorf("variable %q is not set", name)
+			}
+			return value, nil
+		},
+	}
+
+	return func(text string) (string, error) {
+		if !strings.Contains(text, "{{") {
+			return text, nil
+		}
+		tmpl, err := template.New("placeholder").Funcs(funcs).Parse(text)
+		if err != nil {
+			return "", errutil.NewAppError(errutil.ErrPlaceholderRender, err)
+		}
+		var out strings.Builder
+		if err := tmpl.Execute(&out, nil); err != nil {
+			return "", errutil.NewAppError(errutil.ErrPlaceholderRender, err)
+		}
+		return out.String(), nil
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"NotificationManagement/models"
 	"NotificationManagement/utils/errutil"
 	"fmt"
+	"net/url"
 
 	"gorm.io/gorm"
 
@@ -35,15 +36,24 @@ type AIModelRequest struct {
 func (r *AIModelRequest) Validate() error {
 	rules := []*validation.FieldRules{
 		validation.Field(&r.Name, validation.Required, validation.Length(1, 255)),
-		validation.Field(&r.Type, validation.Required, validation.In("deepseek", "local", "openai", "gemini")),
+		validation.Field(&r.Type, validation.Required, validation.In("deepseek", "local", "openai", "gemini", "anthropic")),
 		validation.Field(&r.ModelName, validation.Required, validation.Length(1, 255)),
 	}
 
 	// Add conditional validation based on Type
 	switch r.Type {
-	case "deepseek", "local":
+	case "deepseek":
 		rules = append(rules, validation.Field(&r.BaseURL, validation.Required, validation.Length(1, 500)))
-	case "gemini", "openai":
+	case "local":
+		rules = append(rules, validation.Field(&r.BaseURL, validation.Required, validation.Length(1, 500), validation.By(func(value interface{}) error {
+			u, err := url.Parse(value.(string))
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("must be an http or https URL")
+			}
+			return nil
+		})))
+		rules = append(rules, validation.Field(&r.APISecret, validation.Length(0, 500))) // Optional API key
+	case "gemini", "openai", "anthropic":
 		rules = append(rules, validation.Field(&r.APISecret, validation.Required, validation.Length(1, 500)))
 		rules = append(rules, validation.Field(&r.BaseURL, validation.Length(0, 500))) // Optional BaseURL
 	}
@@ -64,7 +74,14 @@ func (dr *AIModelRequest) ToModel() (models.AIModelInterface, error) {
 		BaseURL: &dr.BaseURL,
 	}
 	switch dr.Type {
-	case "deepseek", "local":
+	case "local":
+		return &models.LocalModel{
+			AIModel:   aiModel,
+			Name:      dr.Name,
+			ModelName: dr.ModelName,
+			APISecret: models.EncryptedString(dr.APISecret),
+		}, nil
+	case "deepseek":
 		return &models.DeepseekModel{
 			AIModel:   aiModel,
 			Name:      dr.Name,
@@ -79,6 +96,13 @@ func (dr *AIModelRequest) ToModel() (models.AIModelInterface, error) {
 			APISecret: models.EncryptedString(dr.APISecret),
 		}
 		return geminiModel, nil
+	case "anthropic":
+		return &models.AnthropicModel{
+			AIModel:   aiModel,
+			Name:      dr.Name,
+			ModelName: dr.ModelName,
+			APISecret: models.EncryptedString(dr.APISecret),
+		}, nil
 	case "openai":
 		openaiModel := &models.OpenAIModel{
 			AIModel:   aiModel,
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidAIOutput marks an answer that doesn't carry the structured JSON
+// the model was asked for.
+var ErrInvalidAIOutput = errors.New("invalid AI output")
+
+// AIStatusError is an error status returned by an AI provider's HTTP API.
+type AIStatusError struct {
+	Provider   string
+	StatusCode int
+	Err        error
+}
+
+func (e *AIStatusError) Error() string {
+	return fmt.Sprintf("%s status code %d: %v", e.Provider, e.StatusCode, e.Err)
+}
+
+func (e *AIStatusError) Unwrap() error {
+	return e.Err
+}
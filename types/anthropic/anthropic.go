@@ -0,0 +1,75 @@
+// Package anthropic holds the parts of the Anthropic Messages API used to get
+// structured answers through a forced tool call.
+package anthropic
+
+import (
+	"NotificationManagement/utils/schema"
+	"encoding/json"
+)
+
+const (
+	RoleUser = "user"
+
+	ContentText    = "text"
+	ContentToolUse = "tool_use"
+)
+
+type Request struct {
+	Model      string      `json:"model"`
+	MaxTokens  int         `json:"max_tokens"`
+	System     string      `json:"system,omitempty"`
+	Messages   []Message   `json:"messages"`
+	Tools      []Tool      `json:"tools,omitempty"`
+	ToolChoice *ToolChoice `json:"tool_choice,omitempty"`
+}
+
+type Message struct {
+	Role    string         `json:"role"`
+	Content []ContentBlock `json:"content"`
+}
+
+// ContentBlock is a text block, or a tool_use block in responses.
+type ContentBlock struct {
+	Type  string          `json:"type"`
+	Text  string          `json:"text,omitempty"`
+	ID    string          `json:"id,omitempty"`
+	Name  string          `json:"name,omitempty"`
+	Input json.RawMessage `json:"input,omitempty"`
+}
+
+type Tool struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	InputSchema schema.JSON `json:"input_schema"`
+}
+
+// ToolChoice of type "tool" forces the model to call the named tool.
+type ToolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+type Response struct {
+	ID         string         `json:"id"`
+	Model      string         `json:"model"`
+	Content    []ContentBlock `json:"content"`
+	StopReason string         `json:"stop_reason"`
+}
+
+// ToolInput returns the input of the first call to the named tool.
+func (r *Response) ToolInput(name string) (json.RawMessage, bool) {
+	for _, block := range r.Content {
+		if block.Type == ContentToolUse && block.Name == name {
+			return block.Input, true
+		}
+	}
+	return nil, false
+}
+
+type ErrorResponse struct {
+	Type  string `json:"type"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
@@ -7,4 +7,12 @@ const (
 	ResponseTypeXML  = "xml"
 	ResponseTypeHTML = "html"
 	ResponseTypeText = "text"
+	ResponseTypeCSV  = "csv"
+	ResponseTypeFeed = "feed"
 )
+
+// IsStructuredResponseType reports whether bodies of this type are decoded into
+// JSON-like values rather than kept as text.
+func IsStructuredResponseType(responseType string) bool {
+	return responseType == ResponseTypeJSON || responseType == ResponseTypeCSV || responseType == ResponseTypeFeed
+}
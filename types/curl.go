@@ -2,61 +2,502 @@ package types
 
 import (
 	"NotificationManagement/models"
+	"NotificationManagement/utils"
 	"NotificationManagement/utils/errutil"
+	"NotificationManagement/utils/extractor"
+	"NotificationManagement/utils/gql"
+	"NotificationManagement/utils/importer"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
-	"os"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
 type CurlRequest struct {
-	URL              string                   `json:"url"`
-	Method           string                   `json:"-"`
-	Headers          map[string]string        `json:"-"`
-	Body             string                   `json:"body,omitempty"`
-	RawCurl          string                   `json:"rawCurl,omitempty"`
-	ResponseType     string                   `json:"responseType,omitempty"`
-	UserID           uint                     `json:"user_id"`
-	AdditionalFields []AdditionalFieldRequest `json:"additional_fields"`
+	URL              string                    `json:"url"`
+	Method           string                    `json:"method,omitempty"`
+	Headers          map[string]string         `json:"headers,omitempty"`
+	Body             string                    `json:"body,omitempty"`
+	RawCurl          string                    `json:"rawCurl,omitempty"`
+	ResponseType     string                    `json:"responseType,omitempty"`
+	Extractor        string                    `json:"extractor,omitempty"`
+	ConnectTimeout   *uint                     `json:"connectTimeout,omitempty"`
+	Timeout          *uint                     `json:"timeout,omitempty"`
+	MaxRetries       *uint                     `json:"maxRetries,omitempty"`
+	MaxResponseSize  *int64                    `json:"maxResponseSize,omitempty"`
+	AllowedStatuses  string                    `json:"allowedStatuses,omitempty"`
+	ContentBudget    *uint                     `json:"contentBudget,omitempty"`
+	SnapshotLimit    *uint                     `json:"snapshotLimit,omitempty"`
+	IncludeDiff      bool                      `json:"includeDiff"`
+	AdditionalFields []AdditionalFieldRequest  `json:"additional_fields"`
+	Steps            []RequestStepRequest      `json:"steps,omitempty"`
+	Auth             *RequestAuthRequest       `json:"auth,omitempty"`
+	Pagination       *RequestPaginationRequest `json:"pagination,omitempty"`
+	GraphQL          *RequestGraphQLRequest    `json:"graphql,omitempty"`
+	Transport        *RequestTransportRequest  `json:"transport,omitempty"`
+	PromptTemplateID *uint                     `json:"promptTemplateId,omitempty"`
+	PromptVersion    *uint                     `json:"promptVersion,omitempty"`
 }
 
 func (cr *CurlRequest) Validate() error {
 	return validation.ValidateStruct(cr,
-		validation.Field(&cr.ResponseType, validation.Required, validation.In(ResponseTypeJSON, ResponseTypeXML, ResponseTypeHTML, ResponseTypeText)),
-		validation.Field(&cr.AdditionalFields, validation.Each(validation.By(func(value interface{}) error {
-			if v, ok := value.(AdditionalFieldRequest); ok {
-				return v.Validate()
+		validation.Field(&cr.URL, validation.When(cr.RawCurl == "", validation.Required)),
+		validation.Field(&cr.ResponseType, validation.Required, validation.In(ResponseTypeJSON, ResponseTypeXML, ResponseTypeHTML, ResponseTypeText, ResponseTypeCSV, ResponseTypeFeed)),
+		validation.Field(&cr.Extractor, validation.When(cr.Extractor != "", validation.By(func(value interface{}) error {
+			return extractor.Validate(cr.ResponseType, value.(string))
+		}))),
+		validation.Field(&cr.ConnectTimeout, validation.NilOrNotEmpty, validation.Max(uint(300))),
+		validation.Field(&cr.Timeout, validation.NilOrNotEmpty, validation.Max(uint(600))),
+		validation.Field(&cr.MaxRetries, validation.Max(uint(10))),
+		validation.Field(&cr.MaxResponseSize, validation.NilOrNotEmpty, validation.Min(int64(1))),
+		validation.Field(&cr.ContentBudget, validation.NilOrNotEmpty, validation.Min(uint(1000))),
+		validation.Field(&cr.SnapshotLimit, validation.NilOrNotEmpty, validation.Max(uint(50))),
+		validation.Field(&cr.AllowedStatuses, validation.Length(0, 100), validation.By(func(value interface{}) error {
+			_, err := utils.ParseStatusRanges(value.(string))
+			return err
+		})),
+		validation.Field(&cr.AdditionalFields, validation.By(func(interface{}) error {
+			for _, field := range cr.AdditionalFields {
+				if field.PropertyName == "IsCorrect" {
+					return fmt.Errorf("IsCorrect is reserved for the answer")
+				}
+			}
+			return validateFields(cr.AdditionalFields, 1)
+		})),
+		validation.Field(&cr.Steps, validation.Length(0, 10)),
+		validation.Field(&cr.PromptVersion, validation.When(cr.PromptTemplateID == nil, validation.Nil.Error("needs a promptTemplateId")), validation.NilOrNotEmpty),
+		validation.Field(&cr.Auth),
+		validation.Field(&cr.Transport),
+		validation.Field(&cr.Pagination, validation.When(cr.Pagination != nil && cr.ResponseType != ResponseTypeJSON,
+			validation.By(func(interface{}) error {
+				return fmt.Errorf("pagination is only supported for json responses")
+			})),
+			validation.When(cr.Pagination != nil && cr.GraphQL != nil, validation.By(func(interface{}) error {
+				return fmt.Errorf("GraphQL sources page through query variables, not pagination")
+			}))),
+		validation.Field(&cr.GraphQL, validation.When(cr.GraphQL != nil && cr.ResponseType != ResponseTypeJSON,
+			validation.By(func(interface{}) error {
+				return fmt.Errorf("GraphQL sources need the json response type")
+			}))),
+	)
+}
+
+// RequestTransportRequest configures the proxy and TLS settings of a source,
+// see models.RequestTransport. Secrets left empty on update keep their stored
+// values; the certificate and key are replaced together and only removed with
+// clearClientCert.
+type RequestTransportRequest struct {
+	ProxyURL        string `json:"proxyUrl,omitempty"`
+	ProxyUsername   string `json:"proxyUsername,omitempty"`
+	ProxyPassword   string `json:"proxyPassword,omitempty"`
+	CABundle        string `json:"caBundle,omitempty"`
+	ClientCert      string `json:"clientCert,omitempty"`
+	ClientKey       string `json:"clientKey,omitempty"`
+	ClearClientCert bool   `json:"clearClientCert,omitempty"`
+}
+
+func (tr *RequestTransportRequest) Validate() error {
+	return validation.ValidateStruct(tr,
+		validation.Field(&tr.ProxyURL, validation.By(func(value interface{}) error {
+			if value.(string) == "" {
+				return nil
+			}
+			u, err := url.Parse(value.(string))
+			if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") {
+				return fmt.Errorf("must be an http, https or socks5 URL")
 			}
-			if v, ok := value.(*AdditionalFieldRequest); ok {
-				return v.Validate()
+			if u.User != nil {
+				return fmt.Errorf("set the proxy credentials in proxyUsername and proxyPassword")
 			}
-			return nil // Or return an error if the type is unexpected
+			return nil
+		})),
+		validation.Field(&tr.ProxyUsername, validation.Length(0, 255)),
+		validation.Field(&tr.CABundle, validation.By(func(value interface{}) error {
+			if value.(string) != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(value.(string))) {
+				return fmt.Errorf("must contain PEM encoded certificates")
+			}
+			return nil
+		})),
+		validation.Field(&tr.ClientCert, validation.When(tr.ClientKey != "", validation.Required)),
+		validation.Field(&tr.ClientKey, validation.When(tr.ClientCert != "", validation.Required, validation.By(func(interface{}) error {
+			_, err := tls.X509KeyPair([]byte(tr.ClientCert), []byte(tr.ClientKey))
+			return err
 		}))),
+		validation.Field(&tr.ClearClientCert, validation.When(tr.ClientCert != "" || tr.ClientKey != "", validation.Empty.Error("cannot be combined with a new client certificate"))),
 	)
 }
 
-type AdditionalFieldRequest struct {
-	PropertyName string `json:"property_name"`
+func (tr *RequestTransportRequest) ToModel() models.RequestTransport {
+	if tr == nil {
+		return models.RequestTransport{}
+	}
+	return models.RequestTransport{
+		ProxyURL:        tr.ProxyURL,
+		ProxyUsername:   tr.ProxyUsername,
+		ProxyPassword:   models.EncryptedString(tr.ProxyPassword),
+		CABundle:        tr.CABundle,
+		ClientCert:      models.EncryptedString(tr.ClientCert),
+		ClientKey:       models.EncryptedString(tr.ClientKey),
+		ClearClientCert: tr.ClearClientCert,
+	}
+}
+
+// RequestGraphQLRequest defines a GraphQL source, see models.RequestGraphQL.
+// String variables may use {{secret "name"}} and {{var "name"}} placeholders.
+type RequestGraphQLRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	OperationName string                 `json:"operationName,omitempty"`
+	AllowPartial  bool                   `json:"allowPartial,omitempty"`
+}
+
+func (gr *RequestGraphQLRequest) Validate() error {
+	return validation.ValidateStruct(gr,
+		validation.Field(&gr.Query, validation.Required, validation.By(func(value interface{}) error {
+			return gql.ValidateQuery(value.(string), gr.OperationName, gr.Variables)
+		})),
+		validation.Field(&gr.OperationName, validation.Length(0, 255)),
+	)
+}
+
+func (gr *RequestGraphQLRequest) ToModel() (models.RequestGraphQL, error) {
+	if gr == nil {
+		return models.RequestGraphQL{}, nil
+	}
+	var variables []byte
+	if len(gr.Variables) > 0 {
+		var err error
+		if variables, err = json.Marshal(gr.Variables); err != nil {
+			return models.RequestGraphQL{}, err
+		}
+	}
+	return models.RequestGraphQL{
+		Query:         gr.Query,
+		Variables:     string(variables),
+		OperationName: gr.OperationName,
+		AllowPartial:  gr.AllowPartial,
+	}, nil
+}
+
+// RequestPaginationRequest configures how a JSON source is followed across pages, see models.RequestPagination.
+type RequestPaginationRequest struct {
+	Mode       string `json:"mode"`
+	ItemsPath  string `json:"itemsPath,omitempty"`
+	CursorPath string `json:"cursorPath,omitempty"`
+	Param      string `json:"param,omitempty"`
+	MaxPages   *uint  `json:"maxPages,omitempty"`
+}
+
+func (pr *RequestPaginationRequest) Validate() error {
+	jsonPath := validation.By(func(value interface{}) error {
+		return extractor.Validate(extractor.TypeJSON, value.(string))
+	})
+	return validation.ValidateStruct(pr,
+		validation.Field(&pr.Mode, validation.Required, validation.In(models.PaginationLink, models.PaginationCursor, models.PaginationPage, models.PaginationOffset)),
+		validation.Field(&pr.ItemsPath, validation.When(pr.ItemsPath != "", jsonPath)),
+		validation.Field(&pr.CursorPath, validation.When(pr.Mode == models.PaginationCursor, validation.Required, jsonPath)),
+		validation.Field(&pr.Param, validation.When(pr.Mode != models.PaginationLink, validation.Required), validation.Length(0, 100)),
+		validation.Field(&pr.MaxPages, validation.NilOrNotEmpty, validation.Max(uint(100))),
+	)
+}
+
+func (pr *RequestPaginationRequest) ToModel() models.RequestPagination {
+	if pr == nil {
+		return models.RequestPagination{}
+	}
+	return models.RequestPagination{
+		Mode:       pr.Mode,
+		ItemsPath:  pr.ItemsPath,
+		CursorPath: pr.CursorPath,
+		Param:      pr.Param,
+		MaxPages:   pr.MaxPages,
+	}
+}
+
+// RequestAuthRequest configures how a source authenticates, see models.RequestAuth.
+// Secrets left empty on update keep their stored values.
+type RequestAuthRequest struct {
 	Type         string `json:"type"`
-	Description  string `json:"description,omitempty"`
-	RequestID    uint   `json:"request_id,omitempty"`
-	ID           uint   `json:"id,omitempty"`
+	TokenURL     string `json:"tokenUrl,omitempty"`
+	ClientID     string `json:"clientId,omitempty"`
+	ClientSecret string `json:"clientSecret,omitempty"`
+	Username     string `json:"username,omitempty"`
+	Password     string `json:"password,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+	Scopes       string `json:"scopes,omitempty"`
+}
+
+func (ar *RequestAuthRequest) Validate() error {
+	oauth := ar.Type == models.AuthClientCredentials || ar.Type == models.AuthRefreshToken
+	return validation.ValidateStruct(ar,
+		validation.Field(&ar.Type, validation.Required, validation.In(models.AuthBasic, models.AuthClientCredentials, models.AuthRefreshToken)),
+		validation.Field(&ar.TokenURL, validation.When(oauth, validation.Required, validation.By(func(value interface{}) error {
+			u, err := url.Parse(value.(string))
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("must be an http or https URL")
+			}
+			return nil
+		}))),
+		validation.Field(&ar.ClientID, validation.When(ar.Type == models.AuthClientCredentials, validation.Required), validation.Length(0, 255)),
+		validation.Field(&ar.Username, validation.When(ar.Type == models.AuthBasic, validation.Required), validation.Length(0, 255)),
+	)
 }
 
+func (ar *RequestAuthRequest) ToModel() models.RequestAuth {
+	if ar == nil {
+		return models.RequestAuth{}
+	}
+	return models.RequestAuth{
+		Type:         ar.Type,
+		TokenURL:     ar.TokenURL,
+		ClientID:     ar.ClientID,
+		ClientSecret: models.EncryptedString(ar.ClientSecret),
+		Username:     ar.Username,
+		Password:     models.EncryptedString(ar.Password),
+		RefreshToken: models.EncryptedString(ar.RefreshToken),
+		Scopes:       ar.Scopes,
+	}
+}
+
+// RequestStepRequest is a request sent before the source request, see models.RequestStep.
+type RequestStepRequest struct {
+	URL      string               `json:"url"`
+	Method   string               `json:"method,omitempty"`
+	Headers  map[string]string    `json:"headers,omitempty"`
+	Body     string               `json:"body,omitempty"`
+	RawCurl  string               `json:"rawCurl,omitempty"`
+	Captures []StepCaptureRequest `json:"captures,omitempty"`
+}
+
+func (sr RequestStepRequest) Validate() error {
+	return validation.ValidateStruct(&sr,
+		validation.Field(&sr.URL, validation.When(sr.RawCurl == "", validation.Required)),
+		validation.Field(&sr.Captures, validation.Length(0, 20)),
+	)
+}
+
+type StepCaptureRequest struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+	Expr string `json:"expr"`
+}
+
+func (cr StepCaptureRequest) Validate() error {
+	return validation.ValidateStruct(&cr,
+		validation.Field(&cr.Name, validation.Required, validation.Length(1, 100), validation.Match(secretNamePattern)),
+		validation.Field(&cr.From, validation.Required, validation.In(models.CaptureFromJSON, models.CaptureFromHeader, models.CaptureFromCookie, models.CaptureFromRegex)),
+		validation.Field(&cr.Expr, validation.Required, validation.By(func(value interface{}) error {
+			switch cr.From {
+			case models.CaptureFromJSON:
+				return extractor.Validate(extractor.TypeJSON, value.(string))
+			case models.CaptureFromRegex:
+				_, err := regexp.Compile(value.(string))
+				return err
+			}
+			return nil
+		})),
+	)
+}
+
+// AdditionalFieldRequest defines a field the AI has to fill in its answer, see
+// models.FieldSpec. Values lists the allowed values of an enum, or of the items
+// of an array of enums; Fields holds the properties of an object.
+type AdditionalFieldRequest struct {
+	PropertyName string                   `json:"property_name"`
+	Type         string                   `json:"type"`
+	Description  string                   `json:"description,omitempty"`
+	Values       []string                 `json:"values,omitempty"`
+	ItemType     string                   `json:"item_type,omitempty"`
+	Required     *bool                    `json:"required,omitempty"`
+	Fields       []AdditionalFieldRequest `json:"fields,omitempty"`
+	RequestID    uint                     `json:"request_id,omitempty"`
+	ID           uint                     `json:"id,omitempty"`
+}
+
+// maxFieldDepth limits how deep objects may nest.
+const maxFieldDepth = 3
+
+var scalarFieldTypes = []interface{}{models.FieldNumber, models.FieldBoolean, models.FieldText, models.FieldEnum, models.FieldDate, models.FieldDateTime}
+
 func (ar *AdditionalFieldRequest) Validate() error {
+	return ar.validate(1)
+}
+
+func (ar *AdditionalFieldRequest) validate(depth int) error {
+	enum := ar.Type == models.FieldEnum || (ar.Type == models.FieldArray && ar.ItemType == models.FieldEnum)
 	return validation.ValidateStruct(ar,
 		validation.Field(&ar.PropertyName, validation.Required, validation.Length(1, 100)),
-		validation.Field(&ar.Type, validation.Required, validation.In("number", "boolean", "text"), validation.Length(1, 10)),
+		validation.Field(&ar.Type, validation.Required, validation.In(append(scalarFieldTypes, models.FieldArray, models.FieldObject)...), validation.Length(1, 10)),
+		validation.Field(&ar.Values, validation.When(enum, validation.Required, validation.Length(1, 100), validation.Each(validation.Required, validation.Length(1, 200))).Else(validation.Empty), validation.By(func(interface{}) error {
+			return uniqueValues(ar.Values)
+		})),
+		validation.Field(&ar.ItemType, validation.When(ar.Type == models.FieldArray, validation.Required, validation.In(scalarFieldTypes...)).Else(validation.Empty)),
+		validation.Field(&ar.Fields, validation.When(ar.Type == models.FieldObject, validation.Required, validation.Length(1, 50), validation.By(func(interface{}) error {
+			if depth >= maxFieldDepth {
+				return fmt.Errorf("objects can be nested at most %d levels deep", maxFieldDepth)
+			}
+			return validateFields(ar.Fields, depth+1)
+		})).Else(validation.Empty)),
 	)
 }
 
+// validateFields validates sibling fields and checks their names are unique.
+func validateFields(fields []AdditionalFieldRequest, depth int) error {
+	errs := validation.Errors{}
+	names := make(map[string]bool, len(fields))
+	for i := range fields {
+		key := strconv.Itoa(i)
+		if err := fields[i].validate(depth); err != nil {
+			errs[key] = err
+			continue
+		}
+		if names[fields[i].PropertyName] {
+			errs[key] = fmt.Errorf("duplicate property name %q", fields[i].PropertyName)
+		}
+		names[fields[i].PropertyName] = true
+	}
+	return errs.Filter()
+}
+
+func uniqueValues(values []string) error {
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		if seen[v] {
+			return fmt.Errorf("duplicate value %q", v)
+		}
+		seen[v] = true
+	}
+	return nil
+}
+
+// Spec converts the field and its nested fields.
+func (ar *AdditionalFieldRequest) Spec() models.FieldSpec {
+	spec := models.FieldSpec{
+		PropertyName: ar.PropertyName,
+		Type:         ar.Type,
+		Description:  ar.Description,
+		Values:       ar.Values,
+		ItemType:     ar.ItemType,
+		Required:     ar.Required,
+	}
+	for i := range ar.Fields {
+		spec.Fields = append(spec.Fields, ar.Fields[i].Spec())
+	}
+	return spec
+}
+
+// ToModel stores Values and Fields as JSON.
+func (ar *AdditionalFieldRequest) ToModel() (models.AdditionalFields, error) {
+	field := models.AdditionalFields{
+		PropertyName: ar.PropertyName,
+		Type:         ar.Type,
+		Description:  ar.Description,
+		ItemType:     ar.ItemType,
+		Required:     ar.Required,
+		RequestID:    ar.RequestID,
+		ID:           ar.ID,
+	}
+	if len(ar.Values) > 0 {
+		values, err := json.Marshal(ar.Values)
+		if err != nil {
+			return models.AdditionalFields{}, err
+		}
+		field.Values = string(values)
+	}
+	if len(ar.Fields) > 0 {
+		spec := ar.Spec()
+		fields, err := json.Marshal(spec.Fields)
+		if err != nil {
+			return models.AdditionalFields{}, err
+		}
+		field.Fields = string(fields)
+	}
+	return field, nil
+}
+
+// ExtractPreviewRequest runs an extractor against a stored request's source
+// without saving it. An empty extractor previews the stored one.
+type ExtractPreviewRequest struct {
+	Extractor string `json:"extractor"`
+}
+
+// CurlImportRequest carries a HAR file or Postman v2.1 collection to import.
+// ResponseType applies to every imported request; when empty it is guessed
+// from recorded responses and defaults to json.
+type CurlImportRequest struct {
+	ResponseType string          `json:"responseType,omitempty"`
+	Content      json.RawMessage `json:"content"`
+}
+
+func (ir *CurlImportRequest) Validate() error {
+	return validation.ValidateStruct(ir,
+		validation.Field(&ir.ResponseType, validation.In(ResponseTypeJSON, ResponseTypeXML, ResponseTypeHTML, ResponseTypeText, ResponseTypeCSV, ResponseTypeFeed)),
+		validation.Field(&ir.Content, validation.Required),
+	)
+}
+
+// CurlImportResponse lists the requests created by an import and the items
+// that were left out.
+type CurlImportResponse struct {
+	Created []*models.CurlRequest `json:"created"`
+	Skipped []importer.Skip       `json:"skipped,omitempty"`
+}
+
+// CurlExportResponse is a stored request rendered as curl commands. Steps run
+// first, in order. Placeholders are kept as written.
+type CurlExportResponse struct {
+	Curl  string   `json:"curl"`
+	Steps []string `json:"steps,omitempty"`
+}
+
+// CurlTestResponse is the result of a dry run of a source definition. Headers
+// show placeholders as written rather than the secrets they resolve to.
+type CurlTestResponse struct {
+	Method           string            `json:"method"`
+	URL              string            `json:"url"`
+	Headers          map[string]string `json:"headers"`
+	FinalURL         string            `json:"final_url,omitempty"`
+	Status           int               `json:"status,omitempty"`
+	DurationMs       int64             `json:"duration_ms"`
+	ResponseHeaders  map[string]string `json:"response_headers,omitempty"`
+	BodyPreview      string            `json:"body_preview,omitempty"`
+	BodyTruncated    bool              `json:"body_truncated,omitempty"`
+	AssistantContent string            `json:"assistant_content,omitempty"`
+	Error            string            `json:"error,omitempty"`
+}
+
+// FetchState is what a reminder remembers of its source between runs.
+type FetchState struct {
+	ContentHash  string
+	ETag         string
+	LastModified string
+}
+
+// SourceChange is the outcome of checking a source against a FetchState. State
+// and the feed GUIDs are only to be stored once the run that used Response
+// succeeded.
+type SourceChange struct {
+	Response     *CurlResponse
+	Changed      bool
+	State        FetchState
+	FeedGUIDs    []string // GUIDs of all items currently in the feed
+	NewFeedGUIDs []string // GUIDs of the items the reminder hasn't reported yet
+}
+
 type CurlResponse struct {
-	Status     int               `json:"status"`
-	Headers    map[string]string `json:"headers"`
-	Body       interface{}       `json:"body"`
-	ErrMessage string            `json:"error,omitempty"`
-	UserID     uint              `json:"user_id,omitempty"`
+	Status      int               `json:"status"`
+	Headers     map[string]string `json:"headers"`
+	Body        interface{}       `json:"body"`
+	ContentHash string            `json:"content_hash,omitempty"`
+	Diff        string            `json:"diff,omitempty"`
+	ErrMessage  string            `json:"error,omitempty"`
+	UserID      uint              `json:"user_id,omitempty"`
 }
 
 func (cr *CurlRequest) ToModel() (*models.CurlRequest, error) {
@@ -64,32 +505,87 @@ func (cr *CurlRequest) ToModel() (*models.CurlRequest, error) {
 	if err != nil {
 		return nil, errutil.NewAppError(errutil.ErrInvalidRequestBody, err)
 	}
-	headersJSON, err := json.Marshal(cr.Headers)
+	var headersJSON []byte
+	if len(cr.Headers) > 0 {
+		headersJSON, err = json.Marshal(cr.Headers)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var props []models.AdditionalFields
+	for i := range cr.AdditionalFields {
+		prop, err := cr.AdditionalFields[i].ToModel()
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, prop)
+	}
+	graphQL, err := cr.GraphQL.ToModel()
 	if err != nil {
 		return nil, err
 	}
-	var props []models.AdditionalFields
-	for _, p := range cr.AdditionalFields {
-		props = append(props, models.AdditionalFields{
-			PropertyName: p.PropertyName,
-			Type:         p.Type,
-			Description:  p.Description,
-			RequestID:    p.RequestID,
-			ID:           p.ID,
-		})
+	steps := make([]models.RequestStep, 0, len(cr.Steps))
+	for i, step := range cr.Steps {
+		model := models.RequestStep{
+			Position: i,
+			URL:      step.URL,
+			Method:   strings.ToUpper(step.Method),
+			Body:     step.Body,
+			RawCurl:  step.RawCurl,
+		}
+		if len(step.Headers) > 0 {
+			headers, err := json.Marshal(step.Headers)
+			if err != nil {
+				return nil, err
+			}
+			model.Headers = string(headers)
+		}
+		if len(step.Captures) > 0 {
+			captures, err := json.Marshal(step.Captures)
+			if err != nil {
+				return nil, err
+			}
+			model.Captures = string(captures)
+		}
+		steps = append(steps, model)
 	}
 	return &models.CurlRequest{
 		URL:              cr.URL,
-		Method:           cr.Method,
+		Method:           strings.ToUpper(cr.Method),
 		Headers:          string(headersJSON),
 		Body:             cr.Body,
 		RawCurl:          cr.RawCurl,
 		ResponseType:     cr.ResponseType,
-		UserID:           cr.UserID,
+		Extractor:        cr.Extractor,
+		ConnectTimeout:   cr.ConnectTimeout,
+		Timeout:          cr.Timeout,
+		MaxRetries:       cr.MaxRetries,
+		MaxResponseSize:  cr.MaxResponseSize,
+		AllowedStatuses:  cr.AllowedStatuses,
+		ContentBudget:    cr.ContentBudget,
+		SnapshotLimit:    cr.SnapshotLimit,
+		IncludeDiff:      cr.IncludeDiff,
 		AdditionalFields: &props,
+		Steps:            &steps,
+		Auth:             cr.Auth.ToModel(),
+		Pagination:       cr.Pagination.ToModel(),
+		GraphQL:          graphQL,
+		Transport:        cr.Transport.ToModel(),
+		PromptTemplateID: cr.PromptTemplateID,
+		PromptVersion:    cr.PromptVersion,
 	}, nil
 }
 
+// GetDiffContent describes what changed since the previous snapshot, or returns
+// nil when no diff was computed for this response.
+func (response *CurlResponse) GetDiffContent() *string {
+	if response.Diff == "" {
+		return nil
+	}
+	s := "Here is what changed since the previous check:\n" + response.Diff
+	return &s
+}
+
 func (response *CurlResponse) GetAssistantContent(respType string) (*string, error) {
 	if response.ErrMessage != "" {
 		return nil, errutil.NewAppError(errutil.ErrExternalServiceError, fmt.Errorf("error : %s", response.ErrMessage))
@@ -107,22 +603,26 @@ func (response *CurlResponse) GetAssistantContent(respType string) (*string, err
 		s := "Here is a json string  `" + string(bodyBytes) + "`"
 		return &s, nil
 	case ResponseTypeHTML:
-		htmlContent, ok := response.Body.(string)
+		pageContent, ok := response.Body.(string)
 		if !ok {
 			return nil, errutil.NewAppError(errutil.ErrCurlInvalidResponseBodyType, fmt.Errorf("response body is not a string for HTML type"))
 		}
-		tmpfile, err := os.CreateTemp("", "response-*.html")
+		s := "Here is the page content as markdown:\n\n" + pageContent
+		return &s, nil
+	case ResponseTypeCSV:
+		bodyBytes, err := json.Marshal(response.Body)
 		if err != nil {
-			return nil, errutil.NewAppError(errutil.ErrCurlCreateTempFileFailed, err)
+			return nil, errutil.NewAppError(errutil.ErrCurlMarshalResponseBodyFailed, err)
 		}
-		defer tmpfile.Close()
-
-		_, err = tmpfile.WriteString(htmlContent)
+		s := "Here are the csv rows as a json array  `" + string(bodyBytes) + "`"
+		return &s, nil
+	case ResponseTypeFeed:
+		bodyBytes, err := json.Marshal(response.Body)
 		if err != nil {
-			return nil, errutil.NewAppError(errutil.ErrCurlWriteTempFileFailed, err)
+			return nil, errutil.NewAppError(errutil.ErrCurlMarshalResponseBodyFailed, err)
 		}
-		filePath := tmpfile.Name()
-		return &filePath, nil
+		s := "Here is the feed with its items as json  `" + string(bodyBytes) + "`"
+		return &s, nil
 	case ResponseTypeXML:
 		xmlContent, ok := response.Body.(string)
 		if !ok {
@@ -0,0 +1,41 @@
+package types
+
+import "fmt"
+
+type FetchErrorKind string
+
+const (
+	FetchErrorTimeout          FetchErrorKind = "timeout"
+	FetchErrorNetwork          FetchErrorKind = "network"
+	FetchErrorStatusNotAllowed FetchErrorKind = "status_not_allowed"
+	FetchErrorTooLarge         FetchErrorKind = "response_too_large"
+	FetchErrorBlocked          FetchErrorKind = "blocked"
+	FetchErrorAuth             FetchErrorKind = "auth_failed"
+	FetchErrorGraphQL          FetchErrorKind = "graphql_errors"
+	FetchErrorRobots           FetchErrorKind = "robots_disallowed"
+	FetchErrorRateLimited      FetchErrorKind = "rate_limited"
+)
+
+// FetchError describes why a source could not be fetched within its policy.
+type FetchError struct {
+	Kind       FetchErrorKind
+	StatusCode int
+	Attempts   int
+	Err        error
+}
+
+func (e *FetchError) Error() string {
+	msg := "fetch " + string(e.Kind)
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(" (status %d)", e.StatusCode)
+	}
+	// Attempts is 0 when the request was refused before being sent.
+	if e.Attempts > 0 {
+		msg += fmt.Sprintf(" after %d attempt(s)", e.Attempts)
+	}
+	return fmt.Sprintf("%s: %v", msg, e.Err)
+}
+
+func (e *FetchError) Unwrap() error {
+	return e.Err
+}
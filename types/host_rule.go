@@ -0,0 +1,59 @@
+package types
+
+import (
+	"NotificationManagement/models"
+	"NotificationManagement/utils/errutil"
+	"NotificationManagement/utils/netguard"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type HostRuleRequest struct {
+	Role    string `json:"role"`
+	Pattern string `json:"pattern"`
+	Action  string `json:"action"`
+}
+
+func (r *HostRuleRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Role, validation.Required, validation.Length(1, 100)),
+		validation.Field(&r.Pattern, validation.Required, validation.Length(1, 255), validation.By(func(value interface{}) error {
+			_, err := netguard.ParseRule(value.(string))
+			return err
+		})),
+		validation.Field(&r.Action, validation.Required, validation.In(models.HostRuleAllow, models.HostRuleDeny)),
+	)
+}
+
+type HostRuleResponse struct {
+	ID        uint   `json:"id"`
+	Role      string `json:"role"`
+	Pattern   string `json:"pattern"`
+	Action    string `json:"action"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func (r *HostRuleRequest) ToModel() (*models.HostRule, error) {
+	err := r.Validate()
+	if err != nil {
+		return nil, errutil.NewAppError(errutil.ErrInvalidRequestBody, err)
+	}
+	return &models.HostRule{
+		Role:    r.Role,
+		Pattern: strings.ToLower(strings.TrimSpace(r.Pattern)),
+		Action:  r.Action,
+	}, nil
+}
+
+func FromHostRuleModel(model *models.HostRule) *HostRuleResponse {
+	return &HostRuleResponse{
+		ID:        model.ID,
+		Role:      model.Role,
+		Pattern:   model.Pattern,
+		Action:    model.Action,
+		CreatedAt: model.CreatedAt.Format(ResponseDateFormat),
+		UpdatedAt: model.UpdatedAt.Format(ResponseDateFormat),
+	}
+}
@@ -11,22 +11,33 @@ type LLMRequest struct {
 	RequestID uint `json:"request_id"`
 	AIModelID uint `json:"ai_model_id"`
 	IsActive  bool `json:"is_active"`
+	// Weight counts with the weighted reminder strategy, 0 means 1.
+	Weight float64 `json:"weight,omitempty"`
+	// Priority orders the request's models, lowest first.
+	Priority int `json:"priority"`
+	// Fallback models are only asked in place of an earlier model that failed
+	// with a retryable provider error.
+	Fallback bool `json:"fallback"`
 }
 
 func (r *LLMRequest) Validate() error {
 	return validation.ValidateStruct(r,
 		validation.Field(&r.RequestID, validation.Required),
 		validation.Field(&r.AIModelID, validation.Required),
+		validation.Field(&r.Weight, validation.Min(0.0)),
 	)
 }
 
 type LLMResponse struct {
-	ID        uint   `json:"id"`
-	RequestID uint   `json:"request_id"`
-	AIModelID uint   `json:"ai_model_id"`
-	IsActive  bool   `json:"is_active"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID        uint    `json:"id"`
+	RequestID uint    `json:"request_id"`
+	AIModelID uint    `json:"ai_model_id"`
+	IsActive  bool    `json:"is_active"`
+	Weight    float64 `json:"weight"`
+	Priority  int     `json:"priority"`
+	Fallback  bool    `json:"fallback"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
 }
 
 // ToModel converts a types.LLMRequest to a models.RequestAIModel
@@ -35,10 +46,17 @@ func (lr *LLMRequest) ToModel() (*models.RequestAIModel, error) {
 	if err != nil {
 		return nil, errutil.NewAppError(errutil.ErrInvalidRequestBody, err)
 	}
+	weight := lr.Weight
+	if weight == 0 {
+		weight = 1
+	}
 	return &models.RequestAIModel{
 		RequestID: lr.RequestID,
 		AiModelID: lr.AIModelID,
 		IsActive:  lr.IsActive,
+		Weight:    weight,
+		Priority:  lr.Priority,
+		Fallback:  lr.Fallback,
 	}, nil
 }
 
@@ -49,6 +67,9 @@ func FromLLMModel(model *models.RequestAIModel) *LLMResponse {
 		RequestID: model.RequestID,
 		AIModelID: model.AiModelID,
 		IsActive:  model.IsActive,
+		Weight:    model.Weight,
+		Priority:  model.Priority,
+		Fallback:  model.Fallback,
 		CreatedAt: model.CreatedAt.Format(ResponseDateFormat),
 		UpdatedAt: model.UpdatedAt.Format(ResponseDateFormat),
 	}
@@ -1,14 +1,18 @@
 package ollama
 
-import "github.com/go-ozzo/ozzo-validation/v4"
+import (
+	"NotificationManagement/utils/schema"
+
+	"github.com/go-ozzo/ozzo-validation/v4"
+)
 
 type Request struct {
-	Model    string     `json:"model"`
-	Messages []*Message `json:"messages"`
-	Stream   bool       `json:"stream"`
-	Format   *Format    `json:"format,omitempty"`
-	Options  *Options   `json:"options,omitempty"`
-	Think    bool       `json:"think,omitempty"`
+	Model    string      `json:"model"`
+	Messages []*Message  `json:"messages"`
+	Stream   bool        `json:"stream"`
+	Format   schema.JSON `json:"format,omitempty"`
+	Options  *Options    `json:"options,omitempty"`
+	Think    bool        `json:"think,omitempty"`
 }
 
 func (r *Request) Validate() error {
@@ -45,35 +49,10 @@ func (m *Message) Validate() error {
 	)
 }
 
-type Format struct {
-	Type       string                    `json:"type"`
-	Properties map[string]FormatProperty `json:"properties"`
-	Required   []string                  `json:"required"`
-}
-
-func (f *Format) Validate() error {
-	return validation.ValidateStruct(f,
-		validation.Field(&f.Type, validation.Required),
-		validation.Field(&f.Properties, validation.Required),
-		validation.Field(&f.Required, validation.Required),
-	)
-}
-
 type Options struct {
 	Temperature float64 `json:"temperature,omitempty"`
 }
 
-type FormatProperty struct {
-	Type        string `json:"type"`
-	Description string `json:"description,omitempty"`
-}
-
-func (p *FormatProperty) Validate() error {
-	return validation.ValidateStruct(p,
-		validation.Field(&p.Type, validation.Required),
-	)
-}
-
 type Response struct {
 	Model              string   `json:"model"`
 	CreatedAt          string   `json:"created_at"`
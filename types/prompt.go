@@ -0,0 +1,73 @@
+package types
+
+import (
+	"NotificationManagement/models"
+	"NotificationManagement/utils/errutil"
+	"NotificationManagement/utils/prompt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Prompt is what the AI services send, whatever the provider: an optional
+// system instruction, the source content and the question.
+type Prompt struct {
+	System   string
+	Context  []string
+	Question string
+}
+
+// PromptTemplateRequest creates a template or, on update, adds a version.
+// System and User are Go templates, see prompt.Data for what they can use.
+type PromptTemplateRequest struct {
+	Name   string `json:"name"`
+	System string `json:"system,omitempty"`
+	User   string `json:"user"`
+}
+
+func (r *PromptTemplateRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&r.System, validation.Length(0, 20000), validation.By(func(value interface{}) error {
+			return prompt.Validate(value.(string))
+		})),
+		validation.Field(&r.User, validation.Required, validation.Length(1, 20000), validation.By(func(value interface{}) error {
+			return prompt.Validate(value.(string))
+		})),
+	)
+}
+
+func (r *PromptTemplateRequest) ToModel(userID uint) (*models.PromptTemplate, *models.PromptTemplateVersion, error) {
+	if err := r.Validate(); err != nil {
+		return nil, nil, errutil.NewAppError(errutil.ErrInvalidRequestBody, err)
+	}
+	return &models.PromptTemplate{
+		UserID: userID,
+		Name:   r.Name,
+	}, &models.PromptTemplateVersion{
+		System: r.System,
+		User:   r.User,
+	}, nil
+}
+
+type PromptTemplateResponse struct {
+	ID            uint                           `json:"id"`
+	Name          string                         `json:"name"`
+	LatestVersion uint                           `json:"latest_version"`
+	Versions      []models.PromptTemplateVersion `json:"versions,omitempty"`
+	CreatedAt     string                         `json:"created_at"`
+	UpdatedAt     string                         `json:"updated_at"`
+}
+
+func FromPromptTemplateModel(model *models.PromptTemplate) *PromptTemplateResponse {
+	response := &PromptTemplateResponse{
+		ID:            model.ID,
+		Name:          model.Name,
+		LatestVersion: model.LatestVersion,
+		CreatedAt:     model.CreatedAt.Format(ResponseDateFormat),
+		UpdatedAt:     model.UpdatedAt.Format(ResponseDateFormat),
+	}
+	if model.Versions != nil {
+		response.Versions = *model.Versions
+	}
+	return response
+}
@@ -3,19 +3,24 @@ package types
 import (
 	"NotificationManagement/models"
 	"NotificationManagement/utils/errutil"
+	"NotificationManagement/utils/rules"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
 type ReminderRequest struct {
-	RequestID     uint       `json:"request_id"`
-	AfterEvery    uint       `json:"after_every"`
-	Message       string     `json:"message"`
-	TriggeredTime time.Time  `json:"triggered_time"`
-	Occurrence    uint       `json:"occurrence"`
-	Recurrence    string     `json:"recurrence"`
-	Upto          *time.Time `json:"upto,omitempty"`
+	RequestID       uint       `json:"request_id"`
+	AfterEvery      uint       `json:"after_every"`
+	Message         string     `json:"message"`
+	TriggeredTime   time.Time  `json:"triggered_time"`
+	Occurrence      uint       `json:"occurrence"`
+	Recurrence      string     `json:"recurrence"`
+	Upto            *time.Time `json:"upto,omitempty"`
+	SkipIfUnchanged bool       `json:"skip_if_unchanged"`
+	Rule            string     `json:"rule,omitempty"`
+	RuleMode        string     `json:"rule_mode,omitempty"`
+	Strategy        string     `json:"strategy,omitempty"`
 }
 
 func (r *ReminderRequest) Validate() error {
@@ -25,6 +30,11 @@ func (r *ReminderRequest) Validate() error {
 		validation.Field(&r.TriggeredTime, validation.Required),
 		validation.Field(&r.AfterEvery, validation.When(r.Recurrence != "once", validation.Required)),
 		validation.Field(&r.Recurrence, validation.Required, validation.In("once", "seconds", "minutes", "hour", "daily", "weekly"), validation.Length(1, 50)),
+		validation.Field(&r.RuleMode, validation.In(models.RuleModeRules, models.RuleModeGate, models.RuleModeOutput)),
+		validation.Field(&r.Rule, validation.When(r.RuleMode != "", validation.Required, validation.Length(1, 4096), validation.By(func(value interface{}) error {
+			return rules.Validate(value.(string))
+		})).Else(validation.Empty.Error("needs a rule_mode"))),
+		validation.Field(&r.Strategy, validation.In(models.StrategyFirstPositive, models.StrategyMajority, models.StrategyUnanimous, models.StrategyWeighted)),
 	)
 }
 
@@ -37,6 +47,10 @@ type ReminderResponse struct {
 	Occurrence      uint       `json:"occurrence"`
 	Recurrence      string     `json:"recurrence"`
 	Upto            *time.Time `json:"upto,omitempty"`
+	SkipIfUnchanged bool       `json:"skip_if_unchanged"`
+	Rule            string     `json:"rule,omitempty"`
+	RuleMode        string     `json:"rule_mode,omitempty"`
+	Strategy        string     `json:"strategy,omitempty"`
 	CreatedAt       string     `json:"created_at"`
 	UpdatedAt       string     `json:"updated_at"`
 }
@@ -56,6 +70,10 @@ func (r *ReminderRequest) ToModel() (*models.Reminder, error) {
 		Upto:            r.Upto,
 		AfterEvery:      r.AfterEvery,
 		NextTriggerTime: r.TriggeredTime,
+		SkipIfUnchanged: r.SkipIfUnchanged,
+		Rule:            r.Rule,
+		RuleMode:        r.RuleMode,
+		Strategy:        r.Strategy,
 	}, nil
 }
 
@@ -69,6 +87,10 @@ func FromReminderModel(model *models.Reminder) *ReminderResponse {
 		Occurrence:      model.Occurrence,
 		Recurrence:      model.Recurrence,
 		Upto:            model.Upto,
+		SkipIfUnchanged: model.SkipIfUnchanged,
+		Rule:            model.Rule,
+		RuleMode:        model.RuleMode,
+		Strategy:        model.Strategy,
 		CreatedAt:       model.CreatedAt.Format(ResponseDateFormat),
 		UpdatedAt:       model.UpdatedAt.Format(ResponseDateFormat),
 	}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"NotificationManagement/models"
+	"NotificationManagement/utils/errutil"
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var secretNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+type SecretRequest struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (r *SecretRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 100), validation.Match(secretNamePattern)),
+		validation.Field(&r.Value, validation.Required),
+	)
+}
+
+// SecretResponse never carries the secret value back to the client.
+type SecretResponse struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func (r *SecretRequest) ToModel(userID uint) (*models.Secret, error) {
+	err := r.Validate()
+	if err != nil {
+		return nil, errutil.NewAppError(errutil.ErrInvalidRequestBody, err)
+	}
+	return &models.Secret{
+		UserID: userID,
+		Name:   r.Name,
+		Value:  models.EncryptedString(r.Value),
+	}, nil
+}
+
+func FromSecretModel(model *models.Secret) *SecretResponse {
+	return &SecretResponse{
+		ID:        model.ID,
+		Name:      model.Name,
+		CreatedAt: model.CreatedAt.Format(ResponseDateFormat),
+		UpdatedAt: model.UpdatedAt.Format(ResponseDateFormat),
+	}
+}
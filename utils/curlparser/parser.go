@@ -0,0 +1,511 @@
+package curlparser
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotCurlCommand = errors.New("command does not start with curl")
+	ErrMissingURL     = errors.New("no URL found in curl command")
+	ErrMultipleURLs   = errors.New("multiple URLs are not supported")
+)
+
+// UnsupportedFlagError is returned when the command contains a curl option
+// that can't be translated into an HTTP request.
+type UnsupportedFlagError struct {
+	Flag   string
+	Reason string
+}
+
+func (e *UnsupportedFlagError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("unsupported curl flag %q: %s", e.Flag, e.Reason)
+	}
+	return fmt.Sprintf("unsupported curl flag %q", e.Flag)
+}
+
+// Request is the structured form of a curl command.
+type Request struct {
+	Method         string            `json:"method"`
+	URL            string            `json:"url"`
+	Headers        map[string]string `json:"headers"`
+	Body           string            `json:"body,omitempty"`
+	Insecure       bool              `json:"insecure,omitempty"`
+	Compressed     bool              `json:"compressed,omitempty"`
+	MaxTime        time.Duration     `json:"max_time,omitempty"`
+	ConnectTimeout time.Duration     `json:"connect_timeout,omitempty"`
+}
+
+type dataKind int
+
+const (
+	dataASCII dataKind = iota
+	dataRaw
+	dataBinary
+	dataURLEncode
+)
+
+type formField struct {
+	name  string
+	value string
+}
+
+type parser struct {
+	req      *Request
+	method   string
+	data     []string
+	form     []formField
+	cookies  []string
+	user     *string
+	getMode  bool
+	headMode bool
+}
+
+type option struct {
+	hasArg bool
+	apply  func(p *parser, flag, value string) error
+}
+
+func ignore(*parser, string, string) error { return nil }
+
+func unsupported(reason string) func(*parser, string, string) error {
+	return func(_ *parser, flag, _ string) error {
+		return &UnsupportedFlagError{Flag: flag, Reason: reason}
+	}
+}
+
+func dataOption(kind dataKind) option {
+	return option{hasArg: true, apply: func(p *parser, flag, value string) error {
+		return p.addData(flag, value, kind)
+	}}
+}
+
+func formOption(literal bool) option {
+	return option{hasArg: true, apply: func(p *parser, flag, value string) error {
+		return p.addForm(flag, value, literal)
+	}}
+}
+
+func durationOption(target func(r *Request) *time.Duration) option {
+	return option{hasArg: true, apply: func(p *parser, flag, value string) error {
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil || seconds < 0 {
+			return fmt.Errorf("invalid value %q for %s", value, flag)
+		}
+		*target(p.req) = time.Duration(seconds * float64(time.Second))
+		return nil
+	}}
+}
+
+var options map[string]option
+
+func init() {
+	setMethod := option{hasArg: true, apply: func(p *parser, _, value string) error {
+		p.method = strings.ToUpper(value)
+		return nil
+	}}
+	header := option{hasArg: true, apply: func(p *parser, flag, value string) error {
+		return p.addHeader(flag, value)
+	}}
+	setURL := option{hasArg: true, apply: func(p *parser, _, value string) error {
+		return p.setURL(value)
+	}}
+	user := option{hasArg: true, apply: func(p *parser, _, value string) error {
+		p.user = &value
+		return nil
+	}}
+	cookie := option{hasArg: true, apply: func(p *parser, flag, value string) error {
+		if !strings.Contains(value, "=") {
+			return &UnsupportedFlagError{Flag: flag, Reason: "reading cookies from a file is not supported"}
+		}
+		p.cookies = append(p.cookies, value)
+		return nil
+	}}
+	userAgent := option{hasArg: true, apply: func(p *parser, _, value string) error {
+		p.req.Headers["User-Agent"] = value
+		return nil
+	}}
+	referer := option{hasArg: true, apply: func(p *parser, _, value string) error {
+		p.req.Headers["Referer"] = strings.TrimSuffix(value, ";auto")
+		return nil
+	}}
+	jsonData := option{hasArg: true, apply: func(p *parser, flag, value string) error {
+		if err := p.addData(flag, value, dataBinary); err != nil {
+			return err
+		}
+		p.setDefaultHeader("Content-Type", "application/json")
+		p.setDefaultHeader("Accept", "application/json")
+		return nil
+	}}
+	insecure := option{apply: func(p *parser, _, _ string) error {
+		p.req.Insecure = true
+		return nil
+	}}
+	compressed := option{apply: func(p *parser, _, _ string) error {
+		p.req.Compressed = true
+		return nil
+	}}
+	get := option{apply: func(p *parser, _, _ string) error {
+		p.getMode = true
+		return nil
+	}}
+	head := option{apply: func(p *parser, _, _ string) error {
+		p.headMode = true
+		return nil
+	}}
+	maxTime := durationOption(func(r *Request) *time.Duration { return &r.MaxTime })
+	connectTimeout := durationOption(func(r *Request) *time.Duration { return &r.ConnectTimeout })
+	noop := option{apply: ignore}
+	noopArg := option{hasArg: true, apply: ignore}
+
+	options = map[string]option{
+		"-X":                setMethod,
+		"--request":         setMethod,
+		"-H":                header,
+		"--header":          header,
+		"--url":             setURL,
+		"-d":                dataOption(dataASCII),
+		"--data":            dataOption(dataASCII),
+		"--data-ascii":      dataOption(dataASCII),
+		"--data-raw":        dataOption(dataRaw),
+		"--data-binary":     dataOption(dataBinary),
+		"--data-urlencode":  dataOption(dataURLEncode),
+		"--json":            jsonData,
+		"-F":                formOption(false),
+		"--form":            formOption(false),
+		"--form-string":     formOption(true),
+		"-u":                user,
+		"--user":            user,
+		"-b":                cookie,
+		"--cookie":          cookie,
+		"-A":                userAgent,
+		"--user-agent":      userAgent,
+		"-e":                referer,
+		"--referer":         referer,
+		"-G":                get,
+		"--get":             get,
+		"-I":                head,
+		"--head":            head,
+		"-k":                insecure,
+		"--insecure":        insecure,
+		"--compressed":      compressed,
+		"-m":                maxTime,
+		"--max-time":        maxTime,
+		"--connect-timeout": connectTimeout,
+
+		// Options that only affect curl's own output or connection handling.
+		"-s":                      noop,
+		"--silent":                noop,
+		"-S":                      noop,
+		"--show-error":            noop,
+		"-L":                      noop,
+		"--location":              noop,
+		"--location-trusted":      noop,
+		"-v":                      noop,
+		"--verbose":               noop,
+		"-i":                      noop,
+		"--include":               noop,
+		"-g":                      noop,
+		"--globoff":               noop,
+		"-f":                      noop,
+		"--fail":                  noop,
+		"--fail-with-body":        noop,
+		"-N":                      noop,
+		"--no-buffer":             noop,
+		"-#":                      noop,
+		"--progress-bar":          noop,
+		"--http1.0":               noop,
+		"--http1.1":               noop,
+		"--http2":                 noop,
+		"--http2-prior-knowledge": noop,
+		"--http3":                 noop,
+		"--no-keepalive":          noop,
+		"--path-as-is":            noop,
+		"--tr-encoding":           noop,
+		"-c":                      noopArg,
+		"--cookie-jar":            noopArg,
+		"--max-redirs":            noopArg,
+		"--retry":                 noopArg,
+		"--retry-delay":           noopArg,
+		"--retry-max-time":        noopArg,
+
+		"-o":            {hasArg: true, apply: unsupported("writing output to a file is not supported")},
+		"--output":      {hasArg: true, apply: unsupported("writing output to a file is not supported")},
+		"-T":            {hasArg: true, apply: unsupported("uploading files is not supported")},
+		"--upload-file": {hasArg: true, apply: unsupported("uploading files is not supported")},
+		"-K":            {hasArg: true, apply: unsupported("config files are not supported")},
+		"--config":      {hasArg: true, apply: unsupported("config files are not supported")},
+	}
+}
+
+// Parse converts a curl command line into a structured Request. Any flag that
+// can't be represented is reported through an *UnsupportedFlagError.
+func Parse(command string) (*Request, error) {
+	return ParseExpand(command, nil)
+}
+
+// ExpandFunc rewrites a single argument before it is interpreted, e.g. to
+// resolve placeholders.
+type ExpandFunc func(string) (string, error)
+
+// ParseExpand is like Parse but passes every argument after "curl" through
+// expand once tokenized, so substituted values never need shell quoting.
+func ParseExpand(command string, expand ExpandFunc) (*Request, error) {
+	tokens, err := Tokenize(command)
+	if err != nil {
+		return nil, err
+	}
+	if tokens[0] != "curl" && !strings.HasSuffix(tokens[0], "/curl") && tokens[0] != "curl.exe" {
+		return nil, ErrNotCurlCommand
+	}
+	if expand != nil {
+		for i := 1; i < len(tokens); i++ {
+			if tokens[i], err = expand(tokens[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	p := &parser{req: &Request{Headers: map[string]string{}}}
+	args := tokens[1:]
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			for _, rest := range args[i+1:] {
+				if err := p.setURL(rest); err != nil {
+					return nil, err
+				}
+			}
+			i = len(args)
+		case strings.HasPrefix(arg, "--"):
+			opt, ok := options[arg]
+			if !ok {
+				return nil, &UnsupportedFlagError{Flag: arg}
+			}
+			value := ""
+			if opt.hasArg {
+				if i+1 >= len(args) {
+					return nil, fmt.Errorf("flag %s requires a value", arg)
+				}
+				i++
+				value = args[i]
+			}
+			if err := opt.apply(p, arg, value); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			consumed, err := p.applyShortFlags(arg, args[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			i += consumed
+		default:
+			if err := p.setURL(arg); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return p.finish()
+}
+
+// applyShortFlags handles grouped short flags such as -sSL or -XPOST and
+// returns the number of following arguments it consumed.
+func (p *parser) applyShortFlags(arg string, rest []string) (int, error) {
+	for j := 1; j < len(arg); j++ {
+		flag := "-" + string(arg[j])
+		opt, ok := options[flag]
+		if !ok {
+			return 0, &UnsupportedFlagError{Flag: flag}
+		}
+		if !opt.hasArg {
+			if err := opt.apply(p, flag, ""); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		if j+1 < len(arg) {
+			return 0, opt.apply(p, flag, arg[j+1:])
+		}
+		if len(rest) == 0 {
+			return 0, fmt.Errorf("flag %s requires a value", flag)
+		}
+		return 1, opt.apply(p, flag, rest[0])
+	}
+	return 0, nil
+}
+
+func (p *parser) setURL(value string) error {
+	if p.req.URL != "" {
+		return ErrMultipleURLs
+	}
+	if !strings.Contains(value, "://") {
+		value = "http://" + value
+	}
+	p.req.URL = value
+	return nil
+}
+
+func (p *parser) addHeader(flag, value string) error {
+	name, val, found := strings.Cut(value, ":")
+	if !found {
+		if strings.HasSuffix(value, ";") {
+			// "-H 'X-Empty;'" sends a header with an empty value.
+			p.req.Headers[strings.TrimSpace(strings.TrimSuffix(value, ";"))] = ""
+			return nil
+		}
+		return fmt.Errorf("invalid header %q for %s", value, flag)
+	}
+	name = strings.TrimSpace(name)
+	val = strings.TrimSpace(val)
+	if strings.EqualFold(name, "Cookie") {
+		p.cookies = append(p.cookies, val)
+		return nil
+	}
+	if val == "" {
+		// "-H 'Accept:'" removes a header in curl.
+		p.deleteHeader(name)
+		return nil
+	}
+	p.deleteHeader(name)
+	p.req.Headers[name] = val
+	return nil
+}
+
+func (p *parser) deleteHeader(name string) {
+	for k := range p.req.Headers {
+		if strings.EqualFold(k, name) {
+			delete(p.req.Headers, k)
+		}
+	}
+}
+
+func (p *parser) hasHeader(name string) bool {
+	for k := range p.req.Headers {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) setDefaultHeader(name, value string) {
+	if !p.hasHeader(name) {
+		p.req.Headers[name] = value
+	}
+}
+
+func (p *parser) addData(flag, value string, kind dataKind) error {
+	switch kind {
+	case dataASCII, dataBinary:
+		if strings.HasPrefix(value, "@") {
+			return &UnsupportedFlagError{Flag: flag, Reason: "reading data from a file is not supported"}
+		}
+	case dataURLEncode:
+		encoded, err := urlEncodeData(flag, value)
+		if err != nil {
+			return err
+		}
+		value = encoded
+	}
+	p.data = append(p.data, value)
+	return nil
+}
+
+func urlEncodeData(flag, value string) (string, error) {
+	if idx := strings.IndexAny(value, "=@"); idx >= 0 {
+		name, content := value[:idx], value[idx+1:]
+		if value[idx] == '@' {
+			return "", &UnsupportedFlagError{Flag: flag, Reason: "reading data from a file is not supported"}
+		}
+		if name == "" {
+			return url.QueryEscape(content), nil
+		}
+		return name + "=" + url.QueryEscape(content), nil
+	}
+	return url.QueryEscape(value), nil
+}
+
+func (p *parser) addForm(flag, value string, literal bool) error {
+	name, val, found := strings.Cut(value, "=")
+	if !found {
+		return fmt.Errorf("invalid form field %q for %s", value, flag)
+	}
+	if !literal && (strings.HasPrefix(val, "@") || strings.HasPrefix(val, "<")) {
+		return &UnsupportedFlagError{Flag: flag, Reason: "uploading files is not supported"}
+	}
+	p.form = append(p.form, formField{name: name, value: val})
+	return nil
+}
+
+func (p *parser) finish() (*Request, error) {
+	req := p.req
+	if req.URL == "" {
+		return nil, ErrMissingURL
+	}
+	if len(p.data) > 0 && len(p.form) > 0 {
+		return nil, errors.New("--data and --form can't be combined")
+	}
+
+	if len(p.cookies) > 0 {
+		req.Headers["Cookie"] = strings.Join(p.cookies, "; ")
+	}
+	if p.user != nil && !p.hasHeader("Authorization") {
+		req.Headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(*p.user))
+	}
+
+	method := "GET"
+	data := strings.Join(p.data, "&")
+	switch {
+	case p.headMode:
+		method = "HEAD"
+	case p.getMode:
+		if data != "" {
+			separator := "?"
+			if strings.Contains(req.URL, "?") {
+				separator = "&"
+			}
+			req.URL += separator + data
+		}
+	case len(p.data) > 0:
+		method = "POST"
+		req.Body = data
+		p.setDefaultHeader("Content-Type", "application/x-www-form-urlencoded")
+	case len(p.form) > 0:
+		method = "POST"
+		body, contentType, err := encodeMultipart(p.form)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+		p.deleteHeader("Content-Type")
+		req.Headers["Content-Type"] = contentType
+	}
+	if p.method != "" {
+		method = p.method
+	}
+	req.Method = method
+	return req, nil
+}
+
+func encodeMultipart(fields []formField) (string, string, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for _, f := range fields {
+		if err := writer.WriteField(f.name, f.value); err != nil {
+			return "", "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", "", err
+	}
+	return buf.String(), writer.FormDataContentType(), nil
+}
@@ -0,0 +1,54 @@
+package curlparser
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Render formats r as a curl command line that Parse reads back into the same
+// request. Arguments are single-quoted for POSIX shells and each option is put
+// on its own continuation line.
+func Render(r *Request) string {
+	args := []string{"curl"}
+	method := strings.ToUpper(r.Method)
+	implied := http.MethodGet
+	if r.Body != "" {
+		implied = http.MethodPost
+	}
+	if method != "" && method != implied {
+		args = append(args, "-X "+method)
+	}
+	args = append(args, Quote(r.URL))
+
+	names := make([]string, 0, len(r.Headers))
+	for name := range r.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, "-H "+Quote(name+": "+r.Headers[name]))
+	}
+	if r.Body != "" {
+		args = append(args, "--data-raw "+Quote(r.Body))
+	}
+	if r.Compressed {
+		args = append(args, "--compressed")
+	}
+	if r.Insecure {
+		args = append(args, "--insecure")
+	}
+	if r.ConnectTimeout > 0 {
+		args = append(args, "--connect-timeout "+strconv.FormatFloat(r.ConnectTimeout.Seconds(), 'f', -1, 64))
+	}
+	if r.MaxTime > 0 {
+		args = append(args, "--max-time "+strconv.FormatFloat(r.MaxTime.Seconds(), 'f', -1, 64))
+	}
+	return strings.Join(args, " \\\n  ")
+}
+
+// Quote wraps s in single quotes for a POSIX shell.
+func Quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,229 @@
+package curlparser
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrUnterminatedQuote = errors.New("unterminated quoted string")
+	ErrEmptyCommand      = errors.New("empty curl command")
+)
+
+// Tokenize splits a shell command line into words the way a POSIX shell would.
+// It understands single and double quotes, ANSI-C $'...' strings, backslash
+// escapes and line continuations (both `\` and the Windows cmd `^`).
+func Tokenize(command string) ([]string, error) {
+	var (
+		tokens  []string
+		current strings.Builder
+		inToken bool
+	)
+	flush := func() {
+		if inToken {
+			tokens = append(tokens, current.String())
+			current.Reset()
+			inToken = false
+		}
+	}
+
+	runes := []rune(command)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			flush()
+		case r == '\\':
+			if i+1 >= len(runes) {
+				break
+			}
+			next := runes[i+1]
+			if next == '\n' {
+				i++
+				continue
+			}
+			if next == '\r' && i+2 < len(runes) && runes[i+2] == '\n' {
+				i += 2
+				continue
+			}
+			current.WriteRune(next)
+			inToken = true
+			i++
+		case r == '^' && isLineEnd(runes, i+1):
+			for i+1 < len(runes) && (runes[i+1] == '\r' || runes[i+1] == '\n') {
+				i++
+			}
+		case r == '\'':
+			end := indexRune(runes, i+1, '\'')
+			if end < 0 {
+				return nil, ErrUnterminatedQuote
+			}
+			current.WriteString(string(runes[i+1 : end]))
+			inToken = true
+			i = end
+		case r == '"':
+			end, err := readDoubleQuoted(runes, i+1, &current)
+			if err != nil {
+				return nil, err
+			}
+			inToken = true
+			i = end
+		case r == '$' && i+1 < len(runes) && runes[i+1] == '\'':
+			end, err := readANSIQuoted(runes, i+2, &current)
+			if err != nil {
+				return nil, err
+			}
+			inToken = true
+			i = end
+		case r == '$' && i+1 < len(runes) && runes[i+1] == '"':
+			end, err := readDoubleQuoted(runes, i+2, &current)
+			if err != nil {
+				return nil, err
+			}
+			inToken = true
+			i = end
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+	flush()
+
+	if len(tokens) == 0 {
+		return nil, ErrEmptyCommand
+	}
+	return tokens, nil
+}
+
+func isLineEnd(runes []rune, i int) bool {
+	return i < len(runes) && (runes[i] == '\n' || runes[i] == '\r')
+}
+
+func indexRune(runes []rune, from int, r rune) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// readDoubleQuoted consumes a "..." string starting after the opening quote and
+// returns the index of the closing quote.
+func readDoubleQuoted(runes []rune, from int, out *strings.Builder) (int, error) {
+	for i := from; i < len(runes); i++ {
+		r := runes[i]
+		switch r {
+		case '"':
+			return i, nil
+		case '\\':
+			if i+1 >= len(runes) {
+				return 0, ErrUnterminatedQuote
+			}
+			switch next := runes[i+1]; next {
+			case '"', '\\', '$', '`':
+				out.WriteRune(next)
+				i++
+			case '\n':
+				i++
+			default:
+				out.WriteRune(r)
+			}
+		default:
+			out.WriteRune(r)
+		}
+	}
+	return 0, ErrUnterminatedQuote
+}
+
+// readANSIQuoted consumes a $'...' string starting after the opening quote and
+// returns the index of the closing quote.
+func readANSIQuoted(runes []rune, from int, out *strings.Builder) (int, error) {
+	for i := from; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\'' {
+			return i, nil
+		}
+		if r != '\\' {
+			out.WriteRune(r)
+			continue
+		}
+		if i+1 >= len(runes) {
+			return 0, ErrUnterminatedQuote
+		}
+		i++
+		switch esc := runes[i]; esc {
+		case 'a':
+			out.WriteByte('\a')
+		case 'b':
+			out.WriteByte('\b')
+		case 'e', 'E':
+			out.WriteByte(0x1b)
+		case 'f':
+			out.WriteByte('\f')
+		case 'n':
+			out.WriteByte('\n')
+		case 'r':
+			out.WriteByte('\r')
+		case 't':
+			out.WriteByte('\t')
+		case 'v':
+			out.WriteByte('\v')
+		case '\\', '\'', '"', '?':
+			out.WriteRune(esc)
+		case 'x':
+			n := readDigits(runes, i+1, 2, isHex)
+			if n == 0 {
+				out.WriteString(`\x`)
+				continue
+			}
+			v, _ := strconv.ParseUint(string(runes[i+1:i+1+n]), 16, 8)
+			out.WriteByte(byte(v))
+			i += n
+		case 'u', 'U':
+			maxLen := 4
+			if esc == 'U' {
+				maxLen = 8
+			}
+			n := readDigits(runes, i+1, maxLen, isHex)
+			if n == 0 {
+				out.WriteRune('\\')
+				out.WriteRune(esc)
+				continue
+			}
+			v, _ := strconv.ParseUint(string(runes[i+1:i+1+n]), 16, 32)
+			if !utf8.ValidRune(rune(v)) {
+				v = utf8.RuneError
+			}
+			out.WriteRune(rune(v))
+			i += n
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			n := readDigits(runes, i, 3, isOctal)
+			v, _ := strconv.ParseUint(string(runes[i:i+n]), 8, 8)
+			out.WriteByte(byte(v))
+			i += n - 1
+		default:
+			out.WriteRune('\\')
+			out.WriteRune(esc)
+		}
+	}
+	return 0, ErrUnterminatedQuote
+}
+
+func readDigits(runes []rune, from, maxLen int, accept func(rune) bool) int {
+	n := 0
+	for n < maxLen && from+n < len(runes) && accept(runes[from+n]) {
+		n++
+	}
+	return n
+}
+
+func isHex(r rune) bool {
+	return (r >= '0' && r <= '9') || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')
+}
+
+func isOctal(r rune) bool {
+	return r >= '0' && r <= '7'
+}
@@ -0,0 +1,131 @@
+package diffutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const (
+	OpAdded   = "added"
+	OpRemoved = "removed"
+	OpChanged = "changed"
+)
+
+// Change is a single difference between two JSON documents, addressed by a
+// JSONPath-like path such as $.items[2].price.
+type Change struct {
+	Path string      `json:"path"`
+	Op   string      `json:"op"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// JSON compares two decoded JSON values. Arrays are compared by index.
+func JSON(old, new interface{}) []Change {
+	var changes []Change
+	walk("$", old, new, &changes)
+	return changes
+}
+
+func walk(path string, old, new interface{}, changes *[]Change) {
+	switch o := old.(type) {
+	case map[string]interface{}:
+		n, ok := new.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for _, key := range unionKeys(o, n) {
+			ov, inOld := o[key]
+			nv, inNew := n[key]
+			child := path + "." + key
+			switch {
+			case !inNew:
+				*changes = append(*changes, Change{Path: child, Op: OpRemoved, Old: ov})
+			case !inOld:
+				*changes = append(*changes, Change{Path: child, Op: OpAdded, New: nv})
+			default:
+				walk(child, ov, nv, changes)
+			}
+		}
+		return
+	case []interface{}:
+		n, ok := new.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(o) || i < len(n); i++ {
+			child := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(n):
+				*changes = append(*changes, Change{Path: child, Op: OpRemoved, Old: o[i]})
+			case i >= len(o):
+				*changes = append(*changes, Change{Path: child, Op: OpAdded, New: n[i]})
+			default:
+				walk(child, o[i], n[i], changes)
+			}
+		}
+		return
+	}
+	if !equal(old, new) {
+		*changes = append(*changes, Change{Path: path, Op: OpChanged, Old: old, New: new})
+	}
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func equal(a, b interface{}) bool {
+	ab, errA := json.Marshal(a)
+	bb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ab) == string(bb)
+}
+
+// FormatJSON renders changes one per line, e.g. `~ $.price: 10 -> 12`.
+func FormatJSON(changes []Change) string {
+	var b strings.Builder
+	for _, c := range changes {
+		switch c.Op {
+		case OpAdded:
+			fmt.Fprintf(&b, "+ %s: %s\n", c.Path, compact(c.New))
+		case OpRemoved:
+			fmt.Fprintf(&b, "- %s: %s\n", c.Path, compact(c.Old))
+		default:
+			fmt.Fprintf(&b, "~ %s: %s -> %s\n", c.Path, compact(c.Old), compact(c.New))
+		}
+	}
+	return b.String()
+}
+
+func compact(v interface{}) string {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(out)
+}
+
+// Lines returns a unified diff of two texts with the given number of context lines.
+func Lines(old, new string, context int) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(old),
+		B:        difflib.SplitLines(new),
+		FromFile: "previous",
+		ToFile:   "current",
+		Context:  context,
+	})
+}
@@ -43,9 +43,19 @@ var (
 	ErrTaskInfoRetrievalFailed  = ErrorCode{Code: "TASK_INFO_RETRIEVAL_FAILED", Message: "Failed to retrieve task info", Status: http.StatusInternalServerError}
 	ErrTaskMarshalPayloadFailed = ErrorCode{Code: "TASK_MARSHAL_PAYLOAD_FAILED", Message: "Failed to marshal task payload", Status: http.StatusInternalServerError}
 
-	ErrExternalServiceError       = ErrorCode{Code: "EXTERNAL_SERVICE_ERROR", Message: "External service error", Status: http.StatusBadGateway}
-	ErrCurlCommandExecutionFailed = ErrorCode{Code: "CURL_COMMAND_EXECUTION_FAILED", Message: "Failed to execute curl command", Status: http.StatusInternalServerError}
-	ErrCurlParseError             = ErrorCode{Code: "CURL_PARSE_ERROR", Message: "Failed to parse curl command", Status: http.StatusBadRequest}
+	ErrExternalServiceError = ErrorCode{Code: "EXTERNAL_SERVICE_ERROR", Message: "External service error", Status: http.StatusBadGateway}
+	ErrCurlParseError       = ErrorCode{Code: "CURL_PARSE_ERROR", Message: "Failed to parse curl command", Status: http.StatusBadRequest}
+	ErrImportParseFailed    = ErrorCode{Code: "IMPORT_PARSE_FAILED", Message: "Failed to read the imported requests", Status: http.StatusBadRequest}
+	ErrSourceFetchFailed    = ErrorCode{Code: "SOURCE_FETCH_FAILED", Message: "Failed to fetch the request source", Status: http.StatusBadGateway}
+	ErrSourceAuthFailed     = ErrorCode{Code: "SOURCE_AUTH_FAILED", Message: "Failed to authenticate against the request source", Status: http.StatusBadGateway}
+	ErrTransportConfig      = ErrorCode{Code: "TRANSPORT_CONFIG_INVALID", Message: "Invalid proxy or TLS settings for the request source", Status: http.StatusBadRequest}
+	ErrDestinationBlocked   = ErrorCode{Code: "DESTINATION_BLOCKED", Message: "The request destination is not allowed", Status: http.StatusForbidden}
+	ErrResponseParseFailed  = ErrorCode{Code: "RESPONSE_PARSE_FAILED", Message: "Failed to parse the response body", Status: http.StatusUnprocessableEntity}
+	ErrExtractionFailed     = ErrorCode{Code: "EXTRACTION_FAILED", Message: "Failed to extract content from the response", Status: http.StatusUnprocessableEntity}
+	ErrStepCaptureFailed    = ErrorCode{Code: "STEP_CAPTURE_FAILED", Message: "Failed to capture a value from a request step", Status: http.StatusUnprocessableEntity}
+	ErrPlaceholderRender    = ErrorCode{Code: "PLACEHOLDER_RENDER_FAILED", Message: "Failed to resolve request placeholders", Status: http.StatusBadRequest}
+	ErrPromptRender         = ErrorCode{Code: "PROMPT_RENDER_FAILED", Message: "Failed to render the prompt template", Status: http.StatusUnprocessableEntity}
+	ErrRuleEvaluation       = ErrorCode{Code: "RULE_EVALUATION_FAILED", Message: "Failed to evaluate the reminder rule", Status: http.StatusUnprocessableEntity}
 
 	ErrUnsupportedAIModelType = ErrorCode{Code: "UNSUPPORTED_AI_MODEL_TYPE", Message: "Unsupported AI model type", Status: http.StatusBadRequest}
 	ErrAIMarshalRequestFailed = ErrorCode{Code: "AI_MARSHAL_REQUEST_FAILED", Message: "Failed to marshal AI request", Status: http.StatusInternalServerError}
@@ -55,8 +65,6 @@ var (
 	ErrEmptyResponse                 = ErrorCode{Code: "EMPTY_RESPONSE", Message: "Empty response", Status: http.StatusInternalServerError}
 	ErrCurlMarshalResponseBodyFailed = ErrorCode{Code: "MARSHAL_RESPONSE_BODY_FAILED", Message: "Failed to marshal request body", Status: http.StatusInternalServerError}
 	ErrCurlInvalidResponseBodyType   = ErrorCode{Code: "INVALID_RESPONSE_BODY_TYPE", Message: "Invalid request body type", Status: http.StatusInternalServerError}
-	ErrCurlCreateTempFileFailed      = ErrorCode{Code: "CREATE_TEMP_FILE_FAILED", Message: "Failed to create temporary file for request", Status: http.StatusInternalServerError}
-	ErrCurlWriteTempFileFailed       = ErrorCode{Code: "WRITE_TEMP_FILE_FAILED", Message: "Failed to write to temporary file for request", Status: http.StatusInternalServerError}
 	ErrCurlUnsupportedResponseType   = ErrorCode{Code: "UNSUPPORTED_RESPONSE_TYPE", Message: "Unsupported request type", Status: http.StatusBadRequest}
 
 	ErrGormInvalidSlicePointer = ErrorCode{Code: "GORM_INVALID_SLICE_POINTER", Message: "New items must be a pointer to a slice", Status: http.StatusInternalServerError}
@@ -0,0 +1,136 @@
+package extractor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/PaesslerAG/jsonpath"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+)
+
+const (
+	TypeJSON = "json"
+	TypeXML  = "xml"
+	TypeHTML = "html"
+	TypeCSV  = "csv"
+	TypeFeed = "feed"
+)
+
+var (
+	ErrUnsupportedType = errors.New("extractors are only supported for json, csv, feed, xml and html responses")
+	ErrNoMatch         = errors.New("extractor did not match anything")
+)
+
+// Validate checks that expr compiles for the given response type: JSONPath
+// for json and the decoded csv/feed bodies, XPath for xml and a CSS selector for html.
+func Validate(responseType, expr string) error {
+	var err error
+	switch responseType {
+	case TypeJSON, TypeCSV, TypeFeed:
+		_, err = jsonpath.New(expr)
+	case TypeXML:
+		_, err = xpath.Compile(expr)
+	case TypeHTML:
+		_, err = cascadia.Compile(expr)
+	default:
+		return ErrUnsupportedType
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s extractor: %w", responseType, err)
+	}
+	return nil
+}
+
+// Extract narrows a response body down to the parts matched by expr. JSON
+// bodies may be decoded values or raw strings; XML and HTML bodies are strings
+// and the matched elements are returned as markup joined by newlines.
+func Extract(responseType, expr string, body interface{}) (interface{}, error) {
+	switch responseType {
+	case TypeJSON, TypeCSV, TypeFeed:
+		return extractJSON(expr, body)
+	case TypeXML:
+		return extractXML(expr, body)
+	case TypeHTML:
+		return extractHTML(expr, body)
+	default:
+		return nil, ErrUnsupportedType
+	}
+}
+
+func extractJSON(expr string, body interface{}) (interface{}, error) {
+	if text, ok := body.(string); ok {
+		if err := json.Unmarshal([]byte(text), &body); err != nil {
+			return nil, fmt.Errorf("response is not valid JSON: %w", err)
+		}
+	}
+	eval, err := jsonpath.New(expr)
+	if err != nil {
+		return nil, err
+	}
+	result, err := eval(context.Background(), body)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, ErrNoMatch
+	}
+	if list, ok := result.([]interface{}); ok && len(list) == 0 {
+		return nil, ErrNoMatch
+	}
+	return result, nil
+}
+
+func extractXML(expr string, body interface{}) (interface{}, error) {
+	text, ok := body.(string)
+	if !ok {
+		return nil, fmt.Errorf("response body is not a string for XML type")
+	}
+	doc, err := xmlquery.Parse(strings.NewReader(text))
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := xmlquery.QueryAll(doc, expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, ErrNoMatch
+	}
+	parts := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		parts = append(parts, node.OutputXML(true))
+	}
+	return strings.Join(parts, "\n"), nil
+}
+
+func extractHTML(expr string, body interface{}) (interface{}, error) {
+	text, ok := body.(string)
+	if !ok {
+		return nil, fmt.Errorf("response body is not a string for HTML type")
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(text))
+	if err != nil {
+		return nil, err
+	}
+	selector, err := cascadia.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	selection := doc.FindMatcher(selector)
+	if selection.Length() == 0 {
+		return nil, ErrNoMatch
+	}
+	var parts []string
+	selection.Each(func(_ int, s *goquery.Selection) {
+		if html, err := goquery.OuterHtml(s); err == nil {
+			parts = append(parts, html)
+		}
+	})
+	return strings.Join(parts, "\n"), nil
+}
@@ -0,0 +1,186 @@
+package feed
+
+import (
+	"NotificationManagement/utils/htmltext"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+var ErrNotAFeed = errors.New("document is not an RSS or Atom feed")
+
+type Item struct {
+	GUID      string `json:"guid"`
+	Title     string `json:"title"`
+	Link      string `json:"link,omitempty"`
+	Published string `json:"published,omitempty"`
+	Summary   string `json:"summary,omitempty"`
+}
+
+type Feed struct {
+	Title string `json:"title"`
+	Link  string `json:"link,omitempty"`
+	Items []Item `json:"items"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	GUID        string `xml:"guid"`
+	About       string `xml:"about,attr"`
+	PubDate     string `xml:"pubDate"`
+	Date        string `xml:"date"`
+	Description string `xml:"description"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Links     []atomLink `xml:"link"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	Summary   string     `xml:"summary"`
+	Content   string     `xml:"content"`
+}
+
+// document covers RSS 2.0 (<rss><channel>), RSS 1.0 (<rdf:RDF>) and Atom (<feed>).
+type document struct {
+	XMLName xml.Name
+	Channel struct {
+		Title string    `xml:"title"`
+		Link  string    `xml:"link"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+	Items   []rssItem   `xml:"item"`
+	Title   string      `xml:"title"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// Parse decodes an RSS or Atom document. Every item gets a stable GUID: the
+// feed's own guid/id when present, otherwise its link, otherwise a hash of its
+// title and date.
+func Parse(data []byte) (*Feed, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charset.NewReaderLabel
+	decoder.Strict = false
+
+	var doc document
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(doc.XMLName.Local) {
+	case "rss":
+		return fromRSS(doc.Channel.Title, doc.Channel.Link, doc.Channel.Items), nil
+	case "rdf":
+		return fromRSS(doc.Channel.Title, doc.Channel.Link, doc.Items), nil
+	case "feed":
+		return fromAtom(&doc), nil
+	default:
+		return nil, ErrNotAFeed
+	}
+}
+
+func fromRSS(title, link string, items []rssItem) *Feed {
+	f := &Feed{Title: strings.TrimSpace(title), Link: strings.TrimSpace(link), Items: []Item{}}
+	for _, it := range items {
+		published := firstNonEmpty(it.PubDate, it.Date)
+		f.Items = append(f.Items, Item{
+			GUID:      itemGUID(firstNonEmpty(it.GUID, it.About), it.Link, it.Title, published),
+			Title:     strings.TrimSpace(it.Title),
+			Link:      strings.TrimSpace(it.Link),
+			Published: published,
+			Summary:   readable(it.Description, it.Link),
+		})
+	}
+	return f
+}
+
+func fromAtom(doc *document) *Feed {
+	f := &Feed{Title: strings.TrimSpace(doc.Title), Link: alternateLink(doc.Links), Items: []Item{}}
+	for _, entry := range doc.Entries {
+		link := alternateLink(entry.Links)
+		published := firstNonEmpty(entry.Published, entry.Updated)
+		f.Items = append(f.Items, Item{
+			GUID:      itemGUID(entry.ID, link, entry.Title, published),
+			Title:     strings.TrimSpace(entry.Title),
+			Link:      link,
+			Published: published,
+			Summary:   readable(firstNonEmpty(entry.Summary, entry.Content), link),
+		})
+	}
+	return f
+}
+
+// ToMap returns the feed in the same shape as a decoded JSON body so
+// extractors and prompts can treat it like any other structured response.
+func (f *Feed) ToMap() map[string]interface{} {
+	items := make([]interface{}, 0, len(f.Items))
+	for _, it := range f.Items {
+		items = append(items, map[string]interface{}{
+			"guid":      it.GUID,
+			"title":     it.Title,
+			"link":      it.Link,
+			"published": it.Published,
+			"summary":   it.Summary,
+		})
+	}
+	return map[string]interface{}{
+		"title": f.Title,
+		"link":  f.Link,
+		"items": items,
+	}
+}
+
+func alternateLink(links []atomLink) string {
+	for _, l := range links {
+		if l.Rel == "" || l.Rel == "alternate" {
+			return strings.TrimSpace(l.Href)
+		}
+	}
+	if len(links) > 0 {
+		return strings.TrimSpace(links[0].Href)
+	}
+	return ""
+}
+
+func itemGUID(id, link, title, published string) string {
+	if guid := firstNonEmpty(id, link); guid != "" {
+		return guid
+	}
+	sum := sha1.Sum([]byte(strings.TrimSpace(title) + "\n" + published))
+	return hex.EncodeToString(sum[:])
+}
+
+// readable turns HTML summaries into markdown; plain text passes through unchanged.
+func readable(summary, baseURL string) string {
+	summary = strings.TrimSpace(summary)
+	if !strings.Contains(summary, "<") {
+		return summary
+	}
+	text, err := htmltext.ToMarkdown(summary, baseURL)
+	if err != nil {
+		return summary
+	}
+	return text
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package gql
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+var (
+	ErrNoOperation        = errors.New("query has no operation")
+	ErrAmbiguousOperation = errors.New("query has several operations, an operation name is required")
+)
+
+// ValidateQuery checks that query parses, selects a single query operation
+// (operationName picks one when there are several) and that every required
+// variable without a default is provided.
+func ValidateQuery(query, operationName string, variables map[string]interface{}) error {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return err
+	}
+
+	var operations []*ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		if operation, ok := definition.(*ast.OperationDefinition); ok {
+			operations = append(operations, operation)
+		}
+	}
+	var selected *ast.OperationDefinition
+	switch {
+	case len(operations) == 0:
+		return ErrNoOperation
+	case operationName == "" && len(operations) > 1:
+		return ErrAmbiguousOperation
+	case operationName == "":
+		selected = operations[0]
+	default:
+		for _, operation := range operations {
+			if operation.Name != nil && operation.Name.Value == operationName {
+				selected = operation
+			}
+		}
+		if selected == nil {
+			return fmt.Errorf("operation %q not found in query", operationName)
+		}
+	}
+
+	// Sources are polled, so anything with side effects or a long-lived stream is out.
+	if selected.Operation != ast.OperationTypeQuery {
+		return fmt.Errorf("%s operations can't be used as a source, only queries", selected.Operation)
+	}
+	for _, definition := range selected.VariableDefinitions {
+		if _, required := definition.Type.(*ast.NonNull); !required || definition.DefaultValue != nil {
+			continue
+		}
+		if _, ok := variables[definition.Variable.Name.Value]; !ok {
+			return fmt.Errorf("required variable $%s is not set", definition.Variable.Name.Value)
+		}
+	}
+	return nil
+}
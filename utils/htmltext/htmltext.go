@@ -0,0 +1,271 @@
+package htmltext
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	whitespace = regexp.MustCompile(`[ \t\r\n\f]+`)
+	blankLines = regexp.MustCompile(`\n{3,}`)
+)
+
+// ToMarkdown renders an HTML document as markdown. Scripts, styles and other
+// non-content elements are dropped; links are kept and resolved against
+// baseURL, and tables are rendered as pipe tables.
+func ToMarkdown(document, baseURL string) (string, error) {
+	root, err := html.Parse(strings.NewReader(document))
+	if err != nil {
+		return "", err
+	}
+	c := &converter{}
+	if baseURL != "" {
+		c.base, _ = url.Parse(baseURL)
+	}
+	return cleanup(c.node(root)), nil
+}
+
+// Truncate cuts text to at most budget bytes on a rune boundary. A budget of
+// zero or less disables truncation.
+func Truncate(text string, budget int) string {
+	if budget <= 0 || len(text) <= budget {
+		return text
+	}
+	cut := budget
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	if nl := strings.LastIndexByte(text[:cut], '\n'); nl > cut*4/5 {
+		cut = nl
+	}
+	return text[:cut] + "\n\n[content truncated]"
+}
+
+type converter struct {
+	base *url.URL
+}
+
+func (c *converter) children(n *html.Node) string {
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(c.node(child))
+	}
+	return b.String()
+}
+
+func (c *converter) node(n *html.Node) string {
+	switch n.Type {
+	case html.DocumentNode:
+		return c.children(n)
+	case html.TextNode:
+		return whitespace.ReplaceAllString(n.Data, " ")
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Noscript, atom.Template, atom.Svg, atom.Head,
+		atom.Iframe, atom.Object, atom.Embed, atom.Canvas, atom.Button, atom.Select:
+		return ""
+	case atom.Br:
+		return "\n"
+	case atom.Hr:
+		return "\n\n---\n\n"
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		return block(strings.Repeat("#", level) + " " + inline(c.children(n)))
+	case atom.A:
+		return c.link(n)
+	case atom.Img:
+		alt := strings.TrimSpace(attr(n, "alt"))
+		if alt == "" {
+			return ""
+		}
+		return "![" + alt + "](" + c.resolve(attr(n, "src")) + ")"
+	case atom.Strong, atom.B:
+		return wrap(c.children(n), "**")
+	case atom.Em, atom.I:
+		return wrap(c.children(n), "*")
+	case atom.Code:
+		return wrap(textContent(n), "`")
+	case atom.Pre:
+		return "\n\n```\n" + strings.Trim(textContent(n), "\n") + "\n```\n\n"
+	case atom.Ul, atom.Ol:
+		return block(c.list(n))
+	case atom.Table:
+		return block(c.table(n))
+	case atom.Blockquote:
+		content := strings.TrimSpace(cleanup(c.children(n)))
+		return block("> " + strings.ReplaceAll(content, "\n", "\n> "))
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer,
+		atom.Main, atom.Nav, atom.Aside, atom.Li, atom.Dl, atom.Dt, atom.Dd,
+		atom.Figure, atom.Figcaption, atom.Form, atom.Fieldset, atom.Address, atom.Details, atom.Summary:
+		return block(c.children(n))
+	default:
+		return c.children(n)
+	}
+}
+
+func (c *converter) link(n *html.Node) string {
+	text := inline(c.children(n))
+	href := strings.TrimSpace(attr(n, "href"))
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+		return text
+	}
+	href = c.resolve(href)
+	if text == "" {
+		text = href
+	}
+	return "[" + text + "](" + href + ")"
+}
+
+func (c *converter) resolve(ref string) string {
+	if c.base == nil || ref == "" {
+		return ref
+	}
+	u, err := c.base.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return u.String()
+}
+
+func (c *converter) list(n *html.Node) string {
+	var items []string
+	index := 1
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || child.DataAtom != atom.Li {
+			continue
+		}
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = fmt.Sprintf("%d. ", index)
+			index++
+		}
+		content := strings.TrimSpace(cleanup(c.children(child)))
+		content = strings.ReplaceAll(content, "\n\n", "\n")
+		indent := strings.Repeat(" ", len(marker))
+		items = append(items, marker+strings.ReplaceAll(content, "\n", "\n"+indent))
+	}
+	return strings.Join(items, "\n")
+}
+
+func (c *converter) table(n *html.Node) string {
+	var rows [][]string
+	var collect func(*html.Node)
+	collect = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			switch child.DataAtom {
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				collect(child)
+			case atom.Tr:
+				var cells []string
+				for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type == html.ElementNode && (cell.DataAtom == atom.Td || cell.DataAtom == atom.Th) {
+						text := inline(strings.ReplaceAll(cleanup(c.children(cell)), "\n", " "))
+						cells = append(cells, strings.ReplaceAll(text, "|", `\|`))
+					}
+				}
+				if len(cells) > 0 {
+					rows = append(rows, cells)
+				}
+			}
+		}
+	}
+	collect(n)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	var b strings.Builder
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		b.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		if i == 0 {
+			b.WriteString("|" + strings.Repeat(" --- |", columns) + "\n")
+		}
+	}
+	return b.String()
+}
+
+func attr(n *html.Node, name string) string {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			b.WriteString(node.Data)
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+func block(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+	return "\n\n" + s + "\n\n"
+}
+
+func inline(s string) string {
+	return strings.TrimSpace(whitespace.ReplaceAllString(s, " "))
+}
+
+func wrap(s, marker string) string {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return s
+	}
+	return marker + trimmed + marker
+}
+
+// cleanup trims trailing spaces and the single leading space left over from
+// collapsed text nodes, and squeezes runs of blank lines. Code fences are kept verbatim.
+func cleanup(s string) string {
+	lines := strings.Split(s, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		line = strings.TrimRight(line, " \t")
+		if strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "  ") {
+			line = line[1:]
+		}
+		lines[i] = line
+	}
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
@@ -0,0 +1,90 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type harFile struct {
+	Log struct {
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harEntry struct {
+	Request struct {
+		Method   string     `json:"method"`
+		URL      string     `json:"url"`
+		Headers  []harParam `json:"headers"`
+		PostData *struct {
+			MimeType string     `json:"mimeType"`
+			Text     string     `json:"text"`
+			Params   []harParam `json:"params"`
+		} `json:"postData"`
+	} `json:"request"`
+	Response struct {
+		Content struct {
+			MimeType string `json:"mimeType"`
+		} `json:"content"`
+	} `json:"response"`
+}
+
+type harParam struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ParseHAR reads the entries of a HAR 1.2 archive as recorded by browser dev
+// tools. Recorded values are taken literally.
+func ParseHAR(data []byte) (*Result, error) {
+	var file harFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid HAR file: %w", err)
+	}
+	if file.Log.Entries == nil {
+		return nil, fmt.Errorf("invalid HAR file: no log entries")
+	}
+
+	result := &Result{}
+	for _, entry := range file.Log.Entries {
+		req := entry.Request
+		name := req.Method + " " + req.URL
+		u, err := url.Parse(req.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			result.Skipped = append(result.Skipped, Skip{Name: name, Reason: "not an http or https URL"})
+			continue
+		}
+
+		imported := Entry{
+			Name:         name,
+			Method:       strings.ToUpper(req.Method),
+			URL:          escapePlaceholders(req.URL),
+			Headers:      map[string]string{},
+			ResponseType: responseTypeOf(entry.Response.Content.MimeType),
+		}
+		for _, header := range req.Headers {
+			if keepHeader(header.Name, header.Value) {
+				imported.Headers[http.CanonicalHeaderKey(header.Name)] = escapePlaceholders(header.Value)
+			}
+		}
+		if post := req.PostData; post != nil {
+			body := post.Text
+			if body == "" && len(post.Params) > 0 {
+				form := url.Values{}
+				for _, param := range post.Params {
+					form.Add(param.Name, param.Value)
+				}
+				body = form.Encode()
+			}
+			imported.Body = escapePlaceholders(body)
+			if _, ok := imported.Headers["Content-Type"]; !ok && post.MimeType != "" {
+				imported.Headers["Content-Type"] = post.MimeType
+			}
+		}
+		result.Entries = append(result.Entries, imported)
+	}
+	return result, nil
+}
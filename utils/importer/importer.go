@@ -0,0 +1,111 @@
+// Package importer turns requests captured by other tools (browser HAR files,
+// Postman collections) into plain request definitions.
+package importer
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	FormatHAR     = "har"
+	FormatPostman = "postman"
+)
+
+// Entry is a single imported request. URL, headers and body may contain
+// {{secret "name"}} placeholders; any other literal "{{" is escaped.
+type Entry struct {
+	Name         string
+	Method       string
+	URL          string
+	Headers      map[string]string
+	Body         string
+	ResponseType string // guessed from a recorded response, empty when unknown
+
+	Auth *Auth
+
+	GraphQLQuery     string
+	GraphQLVariables map[string]interface{}
+}
+
+const (
+	AuthBasic             = "basic"
+	AuthClientCredentials = "client_credentials"
+)
+
+// Auth is how an imported request authenticates when it can't be expressed
+// as a plain header.
+type Auth struct {
+	Type         string
+	Username     string
+	Password     string
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       string
+}
+
+// Skip records an item that couldn't be imported and why.
+type Skip struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// Result is the outcome of parsing an export: importable entries in document
+// order and the items that were left out.
+type Result struct {
+	Entries []Entry
+	Skipped []Skip
+}
+
+// Parse reads an export in the given format.
+func Parse(format string, data []byte) (*Result, error) {
+	switch format {
+	case FormatHAR:
+		return ParseHAR(data)
+	case FormatPostman:
+		return ParsePostman(data)
+	}
+	return nil, fmt.Errorf("unsupported import format %q", format)
+}
+
+// skippedHeaders are set by the HTTP client itself. Copying a recorded
+// Accept-Encoding would also stop the client from decompressing responses.
+var skippedHeaders = map[string]bool{
+	"host":              true,
+	"content-length":    true,
+	"connection":        true,
+	"accept-encoding":   true,
+	"transfer-encoding": true,
+	"upgrade":           true,
+	"keep-alive":        true,
+}
+
+func keepHeader(name, value string) bool {
+	return name != "" && value != "" && !strings.HasPrefix(name, ":") && !skippedHeaders[strings.ToLower(name)]
+}
+
+// escapePlaceholders keeps literal "{{" from being read as a placeholder.
+func escapePlaceholders(text string) string {
+	return strings.ReplaceAll(text, "{{", `{{"{{"}}`)
+}
+
+// responseTypeOf maps a content type onto one of the supported response types.
+func responseTypeOf(contentType string) string {
+	contentType = strings.ToLower(contentType)
+	switch {
+	case strings.Contains(contentType, "json"):
+		return "json"
+	case strings.Contains(contentType, "rss"), strings.Contains(contentType, "atom"):
+		return "feed"
+	case strings.Contains(contentType, "html"):
+		return "html"
+	case strings.Contains(contentType, "xml"):
+		return "xml"
+	case strings.Contains(contentType, "csv"):
+		return "csv"
+	case strings.HasPrefix(contentType, "text/"):
+		return "text"
+	}
+	return ""
+}
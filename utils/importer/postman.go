@@ -0,0 +1,358 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+type postmanCollection struct {
+	Info struct {
+		Schema string `json:"schema"`
+	} `json:"info"`
+	Item     []postmanItem     `json:"item"`
+	Variable []postmanKeyValue `json:"variable"`
+	Auth     *postmanAuth      `json:"auth"`
+}
+
+type postmanItem struct {
+	Name    string          `json:"name"`
+	Item    []postmanItem   `json:"item"`
+	Request json.RawMessage `json:"request"`
+	Auth    *postmanAuth    `json:"auth"`
+}
+
+type postmanRequest struct {
+	Method string            `json:"method"`
+	Header []postmanKeyValue `json:"header"`
+	Body   *postmanBody      `json:"body"`
+	URL    json.RawMessage   `json:"url"`
+	Auth   *postmanAuth      `json:"auth"`
+}
+
+type postmanBody struct {
+	Mode       string            `json:"mode"`
+	Raw        string            `json:"raw"`
+	URLEncoded []postmanKeyValue `json:"urlencoded"`
+	GraphQL    *struct {
+		Query     string `json:"query"`
+		Variables string `json:"variables"`
+	} `json:"graphql"`
+	Disabled bool `json:"disabled"`
+}
+
+type postmanURL struct {
+	Raw      string            `json:"raw"`
+	Protocol string            `json:"protocol"`
+	Host     []string          `json:"host"`
+	Path     []string          `json:"path"`
+	Query    []postmanKeyValue `json:"query"`
+}
+
+type postmanKeyValue struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled"`
+}
+
+type postmanAuth struct {
+	Type   string             `json:"type"`
+	Basic  []postmanAuthParam `json:"basic"`
+	Bearer []postmanAuthParam `json:"bearer"`
+	OAuth2 []postmanAuthParam `json:"oauth2"`
+}
+
+// postmanAuthParam values are mostly strings, but some OAuth2 settings are
+// lists or objects.
+type postmanAuthParam struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+func (a *postmanAuth) value(params []postmanAuthParam, key string) string {
+	for _, param := range params {
+		if param.Key == key {
+			value, _ := param.Value.(string)
+			return value
+		}
+	}
+	return ""
+}
+
+var (
+	postmanVariable = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+	secretName      = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+)
+
+// ParsePostman reads the requests of a Postman v2.1 collection, walking
+// folders in order. Collection variables are substituted; any other
+// {{variable}} becomes a {{secret "variable"}} placeholder so its value can be
+// stored as a secret. Auth is inherited from folders and the collection as in
+// Postman; basic and bearer auth are supported.
+func ParsePostman(data []byte) (*Result, error) {
+	var collection postmanCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, fmt.Errorf("invalid Postman collection: %w", err)
+	}
+	if !strings.Contains(collection.Info.Schema, "collection/v2") {
+		return nil, fmt.Errorf("unsupported Postman collection schema %q, export it as v2.1", collection.Info.Schema)
+	}
+
+	variables := map[string]string{}
+	for _, variable := range collection.Variable {
+		if !variable.Disabled {
+			variables[variable.Key] = variable.Value
+		}
+	}
+	result := &Result{}
+	walkPostman(collection.Item, "", collection.Auth, variables, result)
+	return result, nil
+}
+
+func walkPostman(items []postmanItem, folder string, auth *postmanAuth, variables map[string]string, result *Result) {
+	for _, item := range items {
+		name := item.Name
+		if folder != "" {
+			name = folder + " / " + item.Name
+		}
+		itemAuth := inheritAuth(auth, item.Auth)
+		if item.Request == nil {
+			walkPostman(item.Item, name, itemAuth, variables, result)
+			continue
+		}
+		entry, err := postmanEntry(item.Request, itemAuth, variables)
+		if err != nil {
+			result.Skipped = append(result.Skipped, Skip{Name: name, Reason: err.Error()})
+			continue
+		}
+		entry.Name = name
+		result.Entries = append(result.Entries, *entry)
+	}
+}
+
+func inheritAuth(parent, own *postmanAuth) *postmanAuth {
+	if own == nil || own.Type == "inherit" {
+		return parent
+	}
+	return own
+}
+
+func postmanEntry(raw json.RawMessage, auth *postmanAuth, variables map[string]string) (*Entry, error) {
+	var req postmanRequest
+	// A request may be given as just its URL.
+	var rawURL string
+	if json.Unmarshal(raw, &rawURL) == nil {
+		req.URL, _ = json.Marshal(rawURL)
+	} else if err := json.Unmarshal(raw, &req); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	subst := func(text string) (string, error) { return substitute(text, variables) }
+
+	target, err := postmanTarget(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	entry := &Entry{Method: strings.ToUpper(req.Method), Headers: map[string]string{}}
+	if entry.Method == "" {
+		entry.Method = http.MethodGet
+	}
+	if entry.URL, err = subst(target); err != nil {
+		return nil, err
+	}
+	for _, header := range req.Header {
+		if header.Disabled || !keepHeader(header.Key, header.Value) {
+			continue
+		}
+		value, err := subst(header.Value)
+		if err != nil {
+			return nil, err
+		}
+		entry.Headers[http.CanonicalHeaderKey(header.Key)] = value
+	}
+
+	if body := req.Body; body != nil && !body.Disabled {
+		switch body.Mode {
+		case "", "none":
+		case "raw":
+			if entry.Body, err = subst(body.Raw); err != nil {
+				return nil, err
+			}
+		case "urlencoded":
+			form := url.Values{}
+			for _, field := range body.URLEncoded {
+				if !field.Disabled {
+					form.Add(field.Key, field.Value)
+				}
+			}
+			if entry.Body, err = subst(form.Encode()); err != nil {
+				return nil, err
+			}
+			if _, ok := entry.Headers["Content-Type"]; !ok {
+				entry.Headers["Content-Type"] = "application/x-www-form-urlencoded"
+			}
+		case "graphql":
+			if body.GraphQL == nil || body.GraphQL.Query == "" {
+				return nil, fmt.Errorf("GraphQL body without a query")
+			}
+			entry.GraphQLQuery = body.GraphQL.Query
+			if strings.TrimSpace(body.GraphQL.Variables) != "" {
+				if err := json.Unmarshal([]byte(body.GraphQL.Variables), &entry.GraphQLVariables); err != nil {
+					return nil, fmt.Errorf("invalid GraphQL variables: %w", err)
+				}
+				if _, err := substituteValues(entry.GraphQLVariables, variables); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			return nil, fmt.Errorf("unsupported body mode %q", body.Mode)
+		}
+	}
+
+	if err := applyPostmanAuth(entry, inheritAuth(auth, req.Auth), subst); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// applyPostmanAuth sets the auth of entry. OAuth2 with the client credentials
+// grant is kept as such; tokens of other grants need a user in the loop, so
+// they are sent as a bearer token read from a secret named after the token.
+func applyPostmanAuth(entry *Entry, auth *postmanAuth, subst func(string) (string, error)) error {
+	if auth == nil {
+		return nil
+	}
+	param := func(params []postmanAuthParam, key string) (string, error) {
+		return subst(auth.value(params, key))
+	}
+	var err error
+	switch auth.Type {
+	case "noauth":
+	case "basic":
+		entry.Auth = &Auth{Type: AuthBasic}
+		if entry.Auth.Username, err = param(auth.Basic, "username"); err != nil {
+			return err
+		}
+		if entry.Auth.Password, err = param(auth.Basic, "password"); err != nil {
+			return err
+		}
+	case "bearer":
+		token, err := param(auth.Bearer, "token")
+		if err != nil {
+			return err
+		}
+		entry.Headers["Authorization"] = "Bearer " + token
+	case "oauth2":
+		if auth.value(auth.OAuth2, "grant_type") != AuthClientCredentials {
+			name := auth.value(auth.OAuth2, "tokenName")
+			if !secretName.MatchString(name) {
+				name = "access_token"
+			}
+			entry.Headers["Authorization"] = `Bearer {{secret "` + name + `"}}`
+			return nil
+		}
+		entry.Auth = &Auth{Type: AuthClientCredentials}
+		fields := map[string]*string{
+			"accessTokenUrl": &entry.Auth.TokenURL,
+			"clientId":       &entry.Auth.ClientID,
+			"clientSecret":   &entry.Auth.ClientSecret,
+			"scope":          &entry.Auth.Scopes,
+		}
+		for key, target := range fields {
+			if *target, err = param(auth.OAuth2, key); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported auth type %q", auth.Type)
+	}
+	return nil
+}
+
+// postmanTarget returns the request URL, which is either a string or an
+// object whose raw form is preferred.
+func postmanTarget(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 {
+		return "", fmt.Errorf("request has no URL")
+	}
+	var target string
+	if json.Unmarshal(raw, &target) == nil {
+		return target, nil
+	}
+	var u postmanURL
+	if err := json.Unmarshal(raw, &u); err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Raw != "" {
+		return u.Raw, nil
+	}
+	if len(u.Host) == 0 {
+		return "", fmt.Errorf("request has no URL")
+	}
+	target = strings.Join(u.Host, ".")
+	if len(u.Path) > 0 {
+		target += "/" + strings.Join(u.Path, "/")
+	}
+	if u.Protocol != "" {
+		target = u.Protocol + "://" + target
+	}
+	var query []string
+	for _, param := range u.Query {
+		if !param.Disabled {
+			query = append(query, url.QueryEscape(param.Key)+"="+url.QueryEscape(param.Value))
+		}
+	}
+	if len(query) > 0 {
+		target += "?" + strings.Join(query, "&")
+	}
+	return target, nil
+}
+
+// substituteValues resolves variables in the string leaves of decoded JSON.
+func substituteValues(value interface{}, variables map[string]string) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return substitute(v, variables)
+	case map[string]interface{}:
+		for key, item := range v {
+			resolved, err := substituteValues(item, variables)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = resolved
+		}
+	case []interface{}:
+		for i, item := range v {
+			resolved, err := substituteValues(item, variables)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = resolved
+		}
+	}
+	return value, nil
+}
+
+// substitute resolves Postman {{variables}} in text. Postman's dynamic
+// variables such as {{$guid}} have no equivalent and are rejected.
+func substitute(text string, variables map[string]string) (string, error) {
+	var out strings.Builder
+	last := 0
+	for _, match := range postmanVariable.FindAllStringSubmatchIndex(text, -1) {
+		out.WriteString(escapePlaceholders(text[last:match[0]]))
+		last = match[1]
+		name := text[match[2]:match[3]]
+		if value, ok := variables[name]; ok {
+			out.WriteString(escapePlaceholders(value))
+			continue
+		}
+		if !secretName.MatchString(name) {
+			return "", fmt.Errorf("unsupported variable {{%s}}", name)
+		}
+		out.WriteString(`{{secret "` + name + `"}}`)
+	}
+	out.WriteString(escapePlaceholders(text[last:]))
+	return out.String(), nil
+}
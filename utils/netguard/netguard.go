@@ -0,0 +1,183 @@
+package netguard
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// blockedPrefixes lists ranges that are never reachable from user-defined
+// requests unless a rule explicitly allows them, on top of the loopback,
+// private, link-local, multicast and unspecified checks done by netip.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// BlockedError is returned when a destination is rejected by the guard.
+type BlockedError struct {
+	Host   string
+	IP     string
+	Reason string
+}
+
+func (e *BlockedError) Error() string {
+	if e.IP != "" && e.IP != e.Host {
+		return fmt.Sprintf("destination %s (%s) blocked: %s", e.Host, e.IP, e.Reason)
+	}
+	return fmt.Sprintf("destination %s blocked: %s", e.Host, e.Reason)
+}
+
+// Rule matches a host name ("example.com", "*.example.com") or an address
+// range ("10.0.0.0/8", "10.1.2.3").
+type Rule struct {
+	host   string
+	prefix netip.Prefix
+}
+
+func ParseRule(pattern string) (Rule, error) {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if pattern == "" {
+		return Rule{}, fmt.Errorf("empty host pattern")
+	}
+	if prefix, err := netip.ParsePrefix(pattern); err == nil {
+		return Rule{prefix: prefix.Masked()}, nil
+	}
+	if addr, err := netip.ParseAddr(pattern); err == nil {
+		return Rule{prefix: netip.PrefixFrom(addr, addr.BitLen())}, nil
+	}
+	name := strings.TrimPrefix(pattern, "*.")
+	if strings.ContainsAny(name, "*/:@ ") || strings.Trim(name, ".") != name {
+		return Rule{}, fmt.Errorf("invalid host pattern %q", pattern)
+	}
+	return Rule{host: pattern}, nil
+}
+
+func (r Rule) matchesName(host string) bool {
+	if r.host == "" {
+		return false
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if suffix, ok := strings.CutPrefix(r.host, "*."); ok {
+		return strings.HasSuffix(host, "."+suffix)
+	}
+	return host == r.host
+}
+
+func (r Rule) matchesIP(ip netip.Addr) bool {
+	return r.prefix.IsValid() && r.prefix.Contains(ip)
+}
+
+// Policy is the effective set of rules for one request owner. Deny rules always
+// win. When there are allow rules, every destination must match one of them.
+// Allowed destinations may also reach internal addresses.
+type Policy struct {
+	Allow        []Rule
+	Deny         []Rule
+	AllowPrivate bool
+}
+
+func (p *Policy) check(host string, ip netip.Addr) error {
+	allowed := false
+	for _, rule := range p.Deny {
+		if rule.matchesName(host) || rule.matchesIP(ip) {
+			return &BlockedError{Host: host, IP: ip.String(), Reason: "denied by host rule"}
+		}
+	}
+	for _, rule := range p.Allow {
+		if rule.matchesName(host) || rule.matchesIP(ip) {
+			allowed = true
+			break
+		}
+	}
+	if len(p.Allow) > 0 && !allowed {
+		return &BlockedError{Host: host, IP: ip.String(), Reason: "not in the host allow-list"}
+	}
+	if !allowed && !p.AllowPrivate && IsInternal(ip) {
+		return &BlockedError{Host: host, IP: ip.String(), Reason: "internal address"}
+	}
+	return nil
+}
+
+// IsInternal reports whether ip is loopback, private, link-local, multicast,
+// unspecified or in one of the other reserved ranges.
+func IsInternal(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// DialContext returns a dial function that applies the policy to the address
+// actually being connected to, after DNS resolution. Because every connection,
+// including those made for redirects, goes through it, rebinding a name to an
+// internal address after validation doesn't help.
+func (p *Policy) DialContext(timeout time.Duration) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		dialer := &net.Dialer{
+			Timeout:   timeout,
+			KeepAlive: 30 * time.Second,
+			Control: func(_, address string, _ syscall.RawConn) error {
+				ipText, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				ip, err := netip.ParseAddr(ipText)
+				if err != nil {
+					return err
+				}
+				return p.check(host, ip.Unmap())
+			},
+		}
+		return dialer.DialContext(ctx, network, addr)
+	}
+}
+
+// CheckHost applies the policy to a destination reached through a proxy, where
+// the connection itself only goes to the proxy. The name is resolved locally
+// and every address must pass; a name that doesn't resolve here has to be
+// allowed by a host rule.
+func (p *Policy) CheckHost(ctx context.Context, host string) error {
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return p.check(host, ip.Unmap())
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil || len(addrs) == 0 {
+		for _, rule := range p.Deny {
+			if rule.matchesName(host) {
+				return &BlockedError{Host: host, Reason: "denied by host rule"}
+			}
+		}
+		for _, rule := range p.Allow {
+			if rule.matchesName(host) {
+				return nil
+			}
+		}
+		return &BlockedError{Host: host, Reason: "can't be resolved to verify it"}
+	}
+	for _, ip := range addrs {
+		if err := p.check(host, ip.Unmap()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
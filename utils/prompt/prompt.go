@@ -0,0 +1,76 @@
+// Package prompt renders the system and user parts of prompt templates. They
+// are Go templates over Data, e.g.
+//
+//	Here is {{.URL}}: {{.Response}}
+//	{{if .Previous}}It used to be: {{.Previous}}{{end}}
+//	{{.Message}}
+package prompt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Data is what a template can refer to.
+type Data struct {
+	URL      string  // URL of the source
+	Method   string  // HTTP method of the source
+	Response string  // the response as given to the AI, after extraction
+	Previous string  // the last different response, empty on the first change
+	Diff     string  // what changed since the previous response, when diffs are enabled
+	Message  string  // message of the reminder being run, empty outside reminders
+	Fields   []Field // the additional fields the AI has to fill in
+	Now      time.Time
+}
+
+// Field describes an additional field.
+type Field struct {
+	Name        string
+	Type        string
+	Description string
+	Required    bool
+}
+
+var funcs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"trim": strings.TrimSpace,
+}
+
+// Render executes text with data. Unknown keys and fields fail rather than
+// rendering as "<no value>".
+func Render(name, text string, data *Data) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// Validate parses text and renders it with sample data, which catches
+// references to fields Data doesn't have.
+func Validate(text string) error {
+	_, err := Render("validate", text, &Data{
+		URL:      "https://example.com",
+		Method:   "GET",
+		Response: "{}",
+		Fields:   []Field{{Name: "Price", Type: "number", Required: true}},
+		Now:      time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Package rules evaluates the CEL conditions attached to reminders. A rule
+// sees the source response as `response`, its status code as `status`, its
+// headers as `headers` and, once the AI has answered, the structured answer as
+// `fields`, e.g. `response.price < 100 && response.status != "in stock"`.
+package rules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+)
+
+// costLimit bounds the work a single evaluation may do, so a rule iterating
+// over a large response can't stall the worker.
+const costLimit = 1_000_000
+
+var ErrNotBool = errors.New("rule must evaluate to a boolean")
+
+var env *cel.Env
+
+func init() {
+	var err error
+	env, err = cel.NewEnv(
+		cel.Variable("response", cel.DynType),
+		cel.Variable("status", cel.IntType),
+		cel.Variable("headers", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("fields", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Input holds the values a rule can refer to. Fields is empty until the AI has
+// answered.
+type Input struct {
+	Response interface{}
+	Status   int
+	Headers  map[string]string
+	Fields   map[string]interface{}
+}
+
+// Rule is a compiled condition.
+type Rule struct {
+	expr    string
+	program cel.Program
+}
+
+// Compile parses and type-checks expr.
+func Compile(expr string) (*Rule, error) {
+	ast, issues := env.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid rule: %w", issues.Err())
+	}
+	if t := ast.OutputType(); t != cel.BoolType && t != cel.DynType {
+		return nil, fmt.Errorf("%w, not %s", ErrNotBool, t)
+	}
+	program, err := env.Program(ast, cel.CostLimit(costLimit), cel.InterruptCheckFrequency(100))
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule: %w", err)
+	}
+	return &Rule{expr: expr, program: program}, nil
+}
+
+// Validate reports whether expr compiles.
+func Validate(expr string) error {
+	_, err := Compile(expr)
+	return err
+}
+
+func (r *Rule) String() string {
+	return r.expr
+}
+
+// Eval runs the rule. Referring to a missing key is an error rather than
+// false, so a changed response shape doesn't silently stop notifications;
+// use `has(response.x)` to guard optional keys.
+func (r *Rule) Eval(ctx context.Context, in Input) (bool, error) {
+	headers := make(map[string]string, len(in.Headers))
+	for k, v := range in.Headers {
+		headers[strings.ToLower(k)] = v
+	}
+	fields := in.Fields
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+	out, _, err := r.program.ContextEval(ctx, map[string]interface{}{
+		"response": in.Response,
+		"status":   in.Status,
+		"headers":  headers,
+		"fields":   fields,
+	})
+	if err != nil {
+		return false, fmt.Errorf("rule %q failed: %w", r.expr, err)
+	}
+	matched, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("rule %q: %w, got %s", r.expr, ErrNotBool, out.Type().TypeName())
+	}
+	return matched, nil
+}
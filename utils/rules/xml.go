@@ -0,0 +1,107 @@
+package rules
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ParseXML turns an XML document into maps rules can navigate. An element
+// without attributes or children becomes its text; otherwise it becomes a map
+// of its attributes ("@name"), children and text ("#text"). Repeated children
+// become lists. Several top-level elements, as an extractor may return, are
+// merged into one map. Text and attributes written as JSON numbers or booleans
+// become numbers and booleans, so `response.price < 100` works as it does for
+// JSON; anything else, such as "007", stays a string.
+func ParseXML(s string) (map[string]interface{}, error) {
+	decoder := xml.NewDecoder(strings.NewReader(s))
+	decoder.Strict = false
+	root := map[string]interface{}{}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			value, err := parseElement(decoder, start)
+			if err != nil {
+				return nil, err
+			}
+			addChild(root, start.Name.Local, value)
+		}
+	}
+	if len(root) == 0 {
+		return nil, errors.New("no XML elements found")
+	}
+	return root, nil
+}
+
+func parseElement(decoder *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	element := map[string]interface{}{}
+	for _, attr := range start.Attr {
+		element["@"+attr.Name.Local] = scalar(attr.Value)
+	}
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			value, err := parseElement(decoder, t)
+			if err != nil {
+				return nil, err
+			}
+			addChild(element, t.Name.Local, value)
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+			if len(element) == 0 {
+				return scalar(content), nil
+			}
+			if content != "" {
+				element["#text"] = scalar(content)
+			}
+			return element, nil
+		}
+	}
+}
+
+var jsonNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// scalar converts text the way JSON would have typed it.
+func scalar(text string) interface{} {
+	switch text {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if jsonNumber.MatchString(text) {
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f
+		}
+	}
+	return text
+}
+
+func addChild(parent map[string]interface{}, name string, value interface{}) {
+	existing, ok := parent[name]
+	if !ok {
+		parent[name] = value
+		return
+	}
+	if list, ok := existing.([]interface{}); ok {
+		parent[name] = append(list, value)
+		return
+	}
+	parent[name] = []interface{}{existing, value}
+}
@@ -0,0 +1,145 @@
+// Package schema describes the structured answer requested from the AI
+// providers once, and renders it as Gemini, OpenAI and Ollama expect it.
+package schema
+
+import (
+	"encoding/json"
+
+	"google.golang.org/genai"
+)
+
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+
+	FormatDate     = "date"
+	FormatDateTime = "date-time"
+)
+
+// Schema is a provider-neutral subset of JSON Schema.
+type Schema struct {
+	Type        string
+	Description string
+	Format      string
+	Enum        []string
+	Items       *Schema
+	Properties  map[string]*Schema
+	Order       []string // property names in the order they were defined
+	Required    []string
+}
+
+// JSON is a rendered JSON Schema document.
+type JSON map[string]interface{}
+
+func (j JSON) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}(j))
+}
+
+// JSONSchema renders the schema as plain JSON Schema, as Ollama takes it.
+func (s *Schema) JSONSchema() JSON {
+	return s.jsonSchema(false, false)
+}
+
+// StrictJSONSchema renders the schema for OpenAI structured outputs in strict
+// mode: every property is listed as required, optional ones accept null
+// instead, and objects don't allow other properties.
+func (s *Schema) StrictJSONSchema() JSON {
+	return s.jsonSchema(true, false)
+}
+
+func (s *Schema) jsonSchema(strict, nullable bool) JSON {
+	out := JSON{"type": s.Type}
+	if nullable {
+		out["type"] = []string{s.Type, "null"}
+	}
+	if s.Description != "" {
+		out["description"] = s.Description
+	}
+	if s.Format != "" {
+		out["format"] = s.Format
+	}
+	if len(s.Enum) > 0 {
+		values := make([]interface{}, 0, len(s.Enum)+1)
+		for _, value := range s.Enum {
+			values = append(values, value)
+		}
+		if nullable {
+			values = append(values, nil)
+		}
+		out["enum"] = values
+	}
+	if s.Items != nil {
+		out["items"] = s.Items.jsonSchema(strict, false)
+	}
+	if s.Type == TypeObject {
+		required := map[string]bool{}
+		for _, name := range s.Required {
+			required[name] = true
+		}
+		properties := JSON{}
+		for _, name := range s.Order {
+			properties[name] = s.Properties[name].jsonSchema(strict, strict && !required[name])
+		}
+		out["properties"] = properties
+		if strict {
+			out["required"] = append([]string{}, s.Order...)
+			out["additionalProperties"] = false
+		} else {
+			out["required"] = append([]string{}, s.Required...)
+		}
+	}
+	return out
+}
+
+// Genai renders the schema for Gemini, which supports only the date-time
+// string format and marks enums with an "enum" format.
+func (s *Schema) Genai() *genai.Schema {
+	out := &genai.Schema{
+		Type:        genaiTypes[s.Type],
+		Description: s.Description,
+		Enum:        s.Enum,
+		Required:    s.Required,
+	}
+	if s.Format == FormatDateTime {
+		out.Format = s.Format
+	}
+	if len(s.Enum) > 0 {
+		out.Format = "enum"
+	}
+	if s.Items != nil {
+		out.Items = s.Items.Genai()
+	}
+	if s.Type == TypeObject {
+		out.Properties = make(map[string]*genai.Schema, len(s.Properties))
+		for _, name := range s.Order {
+			out.Properties[name] = s.Properties[name].Genai()
+		}
+		out.PropertyOrdering = s.Order
+	}
+	return out
+}
+
+var genaiTypes = map[string]genai.Type{
+	TypeObject:  genai.TypeObject,
+	TypeArray:   genai.TypeArray,
+	TypeString:  genai.TypeString,
+	TypeNumber:  genai.TypeNumber,
+	TypeBoolean: genai.TypeBoolean,
+}
+
+// Add appends a property to an object schema.
+func (s *Schema) Add(name string, property *Schema, required bool) {
+	if s.Properties == nil {
+		s.Properties = map[string]*Schema{}
+	}
+	if _, ok := s.Properties[name]; !ok {
+		s.Order = append(s.Order, name)
+	}
+	s.Properties[name] = property
+	if required {
+		s.Required = append(s.Required, name)
+	}
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StatusRanges is a parsed HTTP status allow-list such as "200-299,304".
+type StatusRanges [][2]int
+
+// ParseStatusRanges parses a comma separated list of codes and ranges. An
+// empty spec means unset; one that lists nothing, such as ",", is an error.
+func ParseStatusRanges(spec string) (StatusRanges, error) {
+	var ranges StatusRanges
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi, found := strings.Cut(part, "-")
+		from, err := strconv.Atoi(strings.TrimSpace(lo))
+		if err != nil {
+			return nil, fmt.Errorf("invalid status code %q", part)
+		}
+		to := from
+		if found {
+			to, err = strconv.Atoi(strings.TrimSpace(hi))
+			if err != nil {
+				return nil, fmt.Errorf("invalid status range %q", part)
+			}
+		}
+		if from < 100 || to > 599 || from > to {
+			return nil, fmt.Errorf("status range %q out of bounds", part)
+		}
+		ranges = append(ranges, [2]int{from, to})
+	}
+	if len(ranges) == 0 && strings.TrimSpace(spec) != "" {
+		return nil, fmt.Errorf("status list %q has no codes", spec)
+	}
+	return ranges, nil
+}
+
+func (r StatusRanges) Allows(code int) bool {
+	for _, rng := range r {
+		if code >= rng[0] && code <= rng[1] {
+			return true
+		}
+	}
+	return false
+}